@@ -0,0 +1,199 @@
+package ilog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrWriterClosed is the error returned when writing to a closed writer.
+var ErrWriterClosed = errors.New("ilog: writer closed")
+
+// OverflowPolicy is the policy of AsyncWriter when its buffer is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks Write until the buffer has space.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest drops the entry that is being written.
+	OverflowDropNewest
+	// OverflowDropOldest drops the oldest entry in the buffer to make space for the entry that is being written.
+	OverflowDropOldest
+)
+
+// AsyncWriterOption is the option of NewAsyncWriter.
+type AsyncWriterOption func(w *AsyncWriter)
+
+// WithAsyncWriterBufferSize sets the number of entries that AsyncWriter can buffer.
+// Default is 1024.
+func WithAsyncWriterBufferSize(size int) AsyncWriterOption {
+	return func(w *AsyncWriter) {
+		if size > 0 {
+			w.ring = make([][]byte, size)
+		}
+	}
+}
+
+// WithAsyncWriterOverflowPolicy sets the policy of AsyncWriter when its buffer is full.
+// Default is OverflowBlock.
+func WithAsyncWriterOverflowPolicy(policy OverflowPolicy) AsyncWriterOption {
+	return func(w *AsyncWriter) {
+		w.policy = policy
+	}
+}
+
+// AsyncWriter is the io.Writer that buffers entries in a bounded ring buffer and writes them to the underlying writer in a background goroutine.
+// The errors returned by the underlying writer are ignored, because the entries have already been reported as written.
+type AsyncWriter struct {
+	dropped uint64
+	w       io.Writer
+	policy  OverflowPolicy
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	// ring is the ring buffer of entries. ring[head] is the oldest entry, and count is the number of buffered entries.
+	ring    [][]byte
+	head    int
+	count   int
+	spare   []byte
+	writing bool
+	closed  bool
+	done    chan struct{}
+}
+
+// NewAsyncWriter returns a new AsyncWriter that writes entries to w in a background goroutine.
+// Close must be called to write the buffered entries and stop the goroutine.
+func NewAsyncWriter(w io.Writer, opts ...AsyncWriterOption) *AsyncWriter {
+	const defaultBufferSize = 1024
+	aw := &AsyncWriter{
+		w:      w,
+		policy: OverflowBlock,
+		ring:   make([][]byte, defaultBufferSize),
+		done:   make(chan struct{}),
+	}
+	aw.cond = sync.NewCond(&aw.mu)
+	for _, opt := range opts {
+		opt(aw)
+	}
+
+	go aw.run()
+
+	return aw
+}
+
+// Write copies p into the buffer. If the buffer is full, it behaves as the OverflowPolicy.
+func (w *AsyncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for !w.closed && w.count == len(w.ring) {
+		switch w.policy {
+		case OverflowDropNewest:
+			atomic.AddUint64(&w.dropped, 1)
+			return len(p), nil
+		case OverflowDropOldest:
+			w.head = (w.head + 1) % len(w.ring)
+			w.count--
+			atomic.AddUint64(&w.dropped, 1)
+		default:
+			w.cond.Wait()
+		}
+	}
+	if w.closed {
+		return 0, ErrWriterClosed
+	}
+
+	i := (w.head + w.count) % len(w.ring)
+	w.ring[i] = append(w.ring[i][:0], p...)
+	w.count++
+	w.cond.Broadcast()
+
+	return len(p), nil
+}
+
+// Dropped returns the number of entries dropped by the OverflowPolicy.
+func (w *AsyncWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&w.dropped)
+}
+
+// Flush waits until the buffered entries are written to the underlying writer, or ctx is done.
+func (w *AsyncWriter) Flush(ctx context.Context) error {
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		select {
+		case <-ctx.Done():
+			w.mu.Lock()
+			w.cond.Broadcast()
+			w.mu.Unlock()
+		case <-stop:
+		}
+	}()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for w.count > 0 || w.writing {
+		if err := ctx.Err(); err != nil {
+			return err //nolint:wrapcheck
+		}
+		w.cond.Wait()
+	}
+
+	return nil
+}
+
+// Sync waits until the buffered entries are written, and flushes the underlying writer if it implements Syncer.
+func (w *AsyncWriter) Sync() error {
+	if err := w.Flush(context.Background()); err != nil {
+		return err
+	}
+	return syncWriterOf(w.w)
+}
+
+// Close stops accepting entries, and waits until the buffered entries are written to the underlying writer, or ctx is done.
+// The underlying writer is not closed. Write after Close returns ErrWriterClosed.
+func (w *AsyncWriter) Close(ctx context.Context) error {
+	w.mu.Lock()
+	w.closed = true
+	w.cond.Broadcast()
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err() //nolint:wrapcheck
+	}
+}
+
+func (w *AsyncWriter) run() {
+	defer close(w.done)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for {
+		for w.count == 0 && !w.closed {
+			w.cond.Wait()
+		}
+		if w.count == 0 {
+			return
+		}
+
+		// NOTE: swap the oldest entry with the spare buffer so that Write can reuse the slot while the entry is being written.
+		entry := w.ring[w.head]
+		w.ring[w.head] = w.spare
+		w.head = (w.head + 1) % len(w.ring)
+		w.count--
+		w.writing = true
+		w.mu.Unlock()
+
+		_, _ = w.w.Write(entry)
+
+		w.mu.Lock()
+		w.spare = entry[:0]
+		w.writing = false
+		w.cond.Broadcast()
+	}
+}
@@ -0,0 +1,155 @@
+package ilog //nolint:testpackage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// testBlockingWriter is the writer that blocks until release is closed, and notifies started when Write is called.
+type testBlockingWriter struct {
+	buf     *bytes.Buffer
+	started chan struct{}
+	release chan struct{}
+}
+
+func newTestBlockingWriter() *testBlockingWriter {
+	return &testBlockingWriter{buf: bytes.NewBuffer(nil), started: make(chan struct{}, 10), release: make(chan struct{})}
+}
+
+func (w *testBlockingWriter) Write(p []byte) (int, error) {
+	w.started <- struct{}{}
+	<-w.release
+	return w.buf.Write(p)
+}
+
+func TestAsyncWriter(t *testing.T) {
+	t.Parallel()
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		w := NewAsyncWriter(buf)
+		l := NewBuilder(DebugLevel, w).SetTimestampKey("").SetCallerKey("").Build()
+		for i := 0; i < 3; i++ {
+			l.Int("i", i).Infof("Infof")
+		}
+
+		if err := w.Flush(context.Background()); err != nil {
+			t.Errorf("❌: err != nil: %v", err)
+		}
+		const expected = `{"severity":"INFO","message":"Infof","i":0}` + "\n" +
+			`{"severity":"INFO","message":"Infof","i":1}` + "\n" +
+			`{"severity":"INFO","message":"Infof","i":2}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+
+		if err := w.Close(context.Background()); err != nil {
+			t.Errorf("❌: err != nil: %v", err)
+		}
+		if _, err := w.Write([]byte("closed")); !errors.Is(err, ErrWriterClosed) {
+			t.Errorf("❌: expected(%v) != actual(%v)", ErrWriterClosed, err)
+		}
+	})
+
+	for _, tt := range []struct {
+		name     string
+		policy   OverflowPolicy
+		expected string
+		dropped  uint64
+	}{
+		{"success,OverflowBlock", OverflowBlock, "123", 0},
+		{"success,OverflowDropNewest", OverflowDropNewest, "12", 1},
+		{"success,OverflowDropOldest", OverflowDropOldest, "13", 1},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			bw := newTestBlockingWriter()
+			w := NewAsyncWriter(bw, WithAsyncWriterBufferSize(1), WithAsyncWriterOverflowPolicy(tt.policy))
+
+			_, _ = w.Write([]byte("1"))
+			<-bw.started // NOTE: "1" is being written, so the buffer is empty.
+			_, _ = w.Write([]byte("2"))
+			written := make(chan struct{})
+			go func() {
+				defer close(written)
+				_, _ = w.Write([]byte("3"))
+			}()
+			if tt.policy == OverflowBlock {
+				select {
+				case <-written:
+					t.Errorf("❌: Write did not block")
+				case <-time.After(10 * time.Millisecond):
+				}
+			} else {
+				<-written
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+			defer cancel()
+			if err := w.Flush(ctx); !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("❌: expected(%v) != actual(%v)", context.DeadlineExceeded, err)
+			}
+
+			close(bw.release)
+			<-written
+			if err := w.Close(context.Background()); err != nil {
+				t.Errorf("❌: err != nil: %v", err)
+			}
+			if actual := bw.buf.String(); tt.expected != actual {
+				t.Errorf("❌: expected(%q) != actual(%q)", tt.expected, actual)
+			}
+			if actual := w.Dropped(); tt.dropped != actual {
+				t.Errorf("❌: expected(%d) != actual(%d)", tt.dropped, actual)
+			}
+		})
+	}
+
+	t.Run("failure,Close,ctx", func(t *testing.T) {
+		t.Parallel()
+		bw := newTestBlockingWriter()
+		defer close(bw.release)
+		w := NewAsyncWriter(bw)
+		_, _ = w.Write([]byte("1"))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := w.Close(ctx); !errors.Is(err, context.Canceled) {
+			t.Errorf("❌: expected(%v) != actual(%v)", context.Canceled, err)
+		}
+	})
+}
+
+//nolint:paralleltest
+func TestAsyncWriter_Global(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	w := NewAsyncWriter(buf)
+	l := NewBuilder(DebugLevel, w).SetTimestampKey("").SetCallerKey("").Build()
+	defer SetGlobal(l)()
+
+	if err := w.Close(context.Background()); err != nil {
+		t.Errorf("❌: err != nil: %v", err)
+	}
+
+	// NOTE: the error of the closed writer must not be logged through the global logger that uses the same writer.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Global().Infof("Infof")
+		_, err := Global().Write([]byte("Write"))
+		if !errors.Is(err, ErrWriterClosed) {
+			t.Errorf("❌: expected(%v) != actual(%v)", ErrWriterClosed, err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("❌: logging after Close did not return")
+	}
+	if expected, actual := "", buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
@@ -1,6 +1,9 @@
 package ilog
 
-import "context"
+import (
+	"context"
+	"sync"
+)
 
 type contextKeyLogger struct{}
 
@@ -24,3 +27,64 @@ func FromContext(ctx context.Context) (logger Logger) { //nolint:ireturn
 func WithContext(ctx context.Context, logger Logger) context.Context {
 	return context.WithValue(ctx, contextKeyLogger{}, logger)
 }
+
+// ContextExtractor returns the log entry with the fields extracted from ctx, such as a request ID, a user ID or a tenant ID.
+// It must return the entry as it is if ctx has no such values.
+type ContextExtractor func(ctx context.Context, entry LogEntry) LogEntry
+
+//nolint:gochecknoglobals
+var (
+	// _contextExtractors is replaced instead of modified by RegisterContextExtractor, so it can be read without lock after contextExtractors returns.
+	_contextExtractors   []ContextExtractor
+	_contextExtractorsMu sync.RWMutex
+)
+
+// RegisterContextExtractor registers the ContextExtractors that Ctx of all the implementations calls in the registered order.
+// It is intended to be called in init or at the beginning of main.
+// The returned rollback restores the ContextExtractors registered before the call, such as in tests:
+//
+//	defer ilog.RegisterContextExtractor(ilog.ContextValue("request_id", requestIDKey{}))()
+func RegisterContextExtractor(extractors ...ContextExtractor) (rollback func()) {
+	_contextExtractorsMu.Lock()
+	defer _contextExtractorsMu.Unlock()
+
+	backup := _contextExtractors
+	registered := make([]ContextExtractor, 0, len(_contextExtractors)+len(extractors))
+	registered = append(registered, _contextExtractors...)
+	_contextExtractors = append(registered, extractors...)
+	return func() {
+		_contextExtractorsMu.Lock()
+		_contextExtractors = backup
+		_contextExtractorsMu.Unlock()
+	}
+}
+
+func contextExtractors() []ContextExtractor {
+	_contextExtractorsMu.RLock()
+	defer _contextExtractorsMu.RUnlock()
+	return _contextExtractors
+}
+
+// ExtractContext returns the log entry with the fields extracted from ctx by the registered ContextExtractors.
+// It is intended for the implementations of Ctx, so that every implementation adds the same fields.
+func ExtractContext(ctx context.Context, entry LogEntry) LogEntry { //nolint:ireturn
+	if ctx == nil {
+		return entry
+	}
+	for _, extract := range contextExtractors() {
+		entry = extract(ctx, entry)
+	}
+	return entry
+}
+
+// ContextValue returns a ContextExtractor that adds the value of ctx.Value(contextKey) as a field with the key, if the value is not nil.
+//
+//	ilog.RegisterContextExtractor(ilog.ContextValue("request_id", requestIDKey{}))
+func ContextValue(key string, contextKey interface{}) ContextExtractor {
+	return func(ctx context.Context, entry LogEntry) LogEntry {
+		if v := ctx.Value(contextKey); v != nil {
+			return entry.Any(key, v)
+		}
+		return entry
+	}
+}
@@ -42,3 +42,42 @@ func TestContext(t *testing.T) {
 		t.Logf("ℹ️: buf:\n%s", buf)
 	})
 }
+
+type testContextKey struct{}
+
+//nolint:paralleltest
+func TestRegisterContextExtractor(t *testing.T) {
+	defer RegisterContextExtractor(ContextValue("request_id", testContextKey{}))()
+	defer RegisterContextExtractor(func(ctx context.Context, entry LogEntry) LogEntry {
+		return entry.String("extractor", "second")
+	})()
+
+	buf := bytes.NewBuffer(nil)
+	defer t.Logf("ℹ️: buf:\n%s", buf)
+	ctx := context.WithValue(context.Background(), testContextKey{}, "req-1")
+	l := NewBuilder(DebugLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").Build()
+
+	l.Ctx(ctx).Infof("Infof")
+	l.String("key", "value").Ctx(context.Background()).Infof("Infof")
+	l.Ctx(nil).Infof("Infof") //nolint:staticcheck
+	Tee(l).Ctx(ctx).Infof("Infof")
+	Tee(l).Bool("bool", true).Ctx(ctx).Infof("Infof")
+
+	const expected = `{"severity":"INFO","message":"Infof","request_id":"req-1","extractor":"second"}` + "\n" +
+		`{"severity":"INFO","message":"Infof","key":"value","extractor":"second"}` + "\n" +
+		`{"severity":"INFO","message":"Infof"}` + "\n" +
+		`{"severity":"INFO","message":"Infof","request_id":"req-1","extractor":"second"}` + "\n" +
+		`{"severity":"INFO","message":"Infof","bool":true,"request_id":"req-1","extractor":"second"}` + "\n"
+	if actual := buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+
+	rollback := RegisterContextExtractor(ContextValue("third", testContextKey{}))
+	if expected, actual := 3, len(contextExtractors()); expected != actual {
+		t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+	}
+	rollback()
+	if expected, actual := 2, len(contextExtractors()); expected != actual {
+		t.Errorf("❌: the extractors before registering are not restored: expected(%d) != actual(%d)", expected, actual)
+	}
+}
@@ -55,3 +55,38 @@ func SetStdLogger(l Logger) (rollback func()) {
 		log.SetOutput(backupWriter)
 	}
 }
+
+// Sync flushes buffered log entries of the global logger if it implements Syncer.
+// It is intended to be called during process exit, e.g. `defer ilog.Sync()` in main.
+func Sync() error {
+	if s, ok := Global().(Syncer); ok {
+		return s.Sync() //nolint:wrapcheck
+	}
+	return nil
+}
+
+//nolint:gochecknoglobals
+var (
+	_exitFunc   = os.Exit
+	_exitFuncMu sync.RWMutex
+)
+
+// SetExitFunc sets the function called by Fatalf after logging, which is os.Exit by default.
+// It is intended to run deferred cleanups before exiting, or to test Fatalf.
+func SetExitFunc(exit func(code int)) (rollback func()) {
+	_exitFuncMu.Lock()
+	backup := _exitFunc
+	_exitFunc = exit
+	_exitFuncMu.Unlock()
+	return func() {
+		SetExitFunc(backup)
+	}
+}
+
+// Exit calls the function set by SetExitFunc. It is called by Fatalf of the implementations of Logger.
+func Exit(code int) {
+	_exitFuncMu.RLock()
+	exit := _exitFunc
+	_exitFuncMu.RUnlock()
+	exit(code)
+}
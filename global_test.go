@@ -76,3 +76,55 @@ func TestSetStdLogger(t *testing.T) {
 		t.Logf("ℹ️: buf:\n%s", buf)
 	})
 }
+
+type testSyncer struct {
+	bytes.Buffer
+	synced int
+}
+
+func (s *testSyncer) Sync() error {
+	s.synced++
+	return nil
+}
+
+//nolint:paralleltest,tparallel
+func TestSync(t *testing.T) {
+	//nolint:paralleltest,tparallel
+	t.Run("success", func(t *testing.T) {
+		ws := &testSyncer{}
+		defer SetGlobal(NewBuilder(DebugLevel, NewAsyncWriter(NewSyncWriter(ws))).SetCallerKey("").SetTimestampKey("").Build())()
+
+		Global().Infof("Infof")
+		if err := Sync(); err != nil {
+			t.Errorf("❌: err != nil: %v", err)
+		}
+
+		if expected, actual := `{"severity":"INFO","message":"Infof"}`+"\n", ws.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+		if expected, actual := 1, ws.synced; expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+	})
+
+	//nolint:paralleltest,tparallel
+	t.Run("success,writerIsNotSyncer", func(t *testing.T) {
+		defer SetGlobal(NewBuilder(DebugLevel, bytes.NewBuffer(nil)).Build())()
+
+		if err := Sync(); err != nil {
+			t.Errorf("❌: err != nil: %v", err)
+		}
+	})
+}
+
+//nolint:paralleltest
+func TestSetExitFunc(t *testing.T) {
+	var code int
+	rollback := SetExitFunc(func(c int) { code = c })
+	Exit(2)
+	rollback()
+
+	if expected, actual := 2, code; expected != actual {
+		t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+	}
+}
@@ -0,0 +1,290 @@
+// Package grpclog provides gRPC interceptors which inject a request-scoped ilog.Logger and write a log per RPC, and grpclog.LoggerV2 on top of ilog.Logger.
+package grpclog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kunitsucom/ilog.go"
+)
+
+// Option is the option of the interceptors.
+type Option func(c *config)
+
+type config struct {
+	payloads bool
+	level    func(code codes.Code) ilog.Level
+	now      func() time.Time
+}
+
+// WithPayloads sets whether to log the request and response messages as "request" and "response" for unary RPCs, or as a log per message for stream RPCs.
+// The messages are logged only if the level of the logger is DebugLevel or lower, and proto.Message is encoded as JSON.
+// Default is false.
+func WithPayloads(payloads bool) Option {
+	return func(c *config) {
+		c.payloads = payloads
+	}
+}
+
+// WithLevel sets the function that returns the level of the log from the status code of the RPC.
+// Default is LevelByCode.
+func WithLevel(level func(code codes.Code) ilog.Level) Option {
+	return func(c *config) {
+		c.level = level
+	}
+}
+
+// LevelByCode returns InfoLevel for the codes caused by the clients, WarnLevel for the codes that may need attention, and ErrorLevel for the codes caused by the servers.
+func LevelByCode(code codes.Code) ilog.Level {
+	switch code {
+	case codes.OK, codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.Unauthenticated:
+		return ilog.InfoLevel
+	case codes.DeadlineExceeded, codes.PermissionDenied, codes.ResourceExhausted, codes.FailedPrecondition, codes.Aborted, codes.OutOfRange:
+		return ilog.WarnLevel
+	default:
+		return ilog.ErrorLevel
+	}
+}
+
+func newConfig(opts ...Option) *config {
+	c := &config{
+		level: LevelByCode,
+		now:   time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// capturePayloads reports whether to log the messages through the logger.
+func (c *config) capturePayloads(l ilog.Logger) bool {
+	return c.payloads && l.Level() <= ilog.DebugLevel
+}
+
+// UnaryServerInterceptor returns grpc.UnaryServerInterceptor that attaches the logger with the fields of the RPC to the context, and writes a log per RPC.
+//
+// The request-scoped logger, which can be retrieved by ilog.FromContext, has the following fields:
+//
+//	method (the full method such as "/package.Service/Method"), peer (if the context has the peer)
+//
+// The log is written through the request-scoped logger with the level chosen by the status code, and has the following fields:
+//
+//	code, duration, error (if the handler returns an error)
+func UnaryServerInterceptor(logger ilog.Logger, opts ...Option) grpc.UnaryServerInterceptor {
+	c := newConfig(opts...)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := c.now()
+		l := requestLogger(ctx, logger, info.FullMethod)
+
+		resp, err := handler(ilog.WithContext(ctx, l), req)
+
+		e := c.rpcLog(l, start, err)
+		if c.capturePayloads(l) {
+			e = e.String("request", payload(req))
+			if err == nil {
+				e = e.String("response", payload(resp))
+			}
+		}
+		code := status.Code(err)
+		e.Logf(c.level(code), "%s %s", info.FullMethod, code)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns grpc.StreamServerInterceptor that attaches the logger with the fields of the RPC to the context of the stream, and writes a log per RPC.
+// The fields are the same as UnaryServerInterceptor.
+func StreamServerInterceptor(logger ilog.Logger, opts ...Option) grpc.StreamServerInterceptor {
+	c := newConfig(opts...)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := c.now()
+		l := requestLogger(ss.Context(), logger, info.FullMethod)
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ilog.WithContext(ss.Context(), l), logger: l, payloads: c.capturePayloads(l)})
+
+		code := status.Code(err)
+		c.rpcLog(l, start, err).Logf(c.level(code), "%s %s", info.FullMethod, code)
+
+		return err
+	}
+}
+
+// UnaryClientInterceptor returns grpc.UnaryClientInterceptor that writes a log per RPC through the logger that ilog.FromContext returns for the context of the RPC.
+//
+// The log has the following fields:
+//
+//	method, target, code, duration, error (if the RPC fails)
+func UnaryClientInterceptor(opts ...Option) grpc.UnaryClientInterceptor {
+	c := newConfig(opts...)
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		start := c.now()
+		l := ilog.FromContext(ctx).String("method", method).String("target", cc.Target()).Logger()
+
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+
+		e := c.rpcLog(l, start, err)
+		if c.capturePayloads(l) {
+			e = e.String("request", payload(req))
+			if err == nil {
+				e = e.String("response", payload(reply))
+			}
+		}
+		code := status.Code(err)
+		e.Logf(c.level(code), "%s %s", method, code)
+
+		return err
+	}
+}
+
+// StreamClientInterceptor returns grpc.StreamClientInterceptor that writes a log per RPC through the logger that ilog.FromContext returns for the context of the RPC.
+// The fields are the same as UnaryClientInterceptor.
+// The log is written when the stream fails to be created, RecvMsg returns an error including io.EOF, RecvMsg receives the response of a client-streaming RPC,
+// or the context of the RPC is done before any of them, e.g. when the client cancels a server-streaming RPC without receiving the messages until the end.
+func StreamClientInterceptor(opts ...Option) grpc.StreamClientInterceptor {
+	c := newConfig(opts...)
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := c.now()
+		l := ilog.FromContext(ctx).String("method", method).String("target", cc.Target()).Logger()
+
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			code := status.Code(err)
+			c.rpcLog(l, start, err).Logf(c.level(code), "%s %s", method, code)
+			return cs, err
+		}
+
+		s := &clientStream{ClientStream: cs, config: c, logger: l, method: method, start: start, serverStreams: desc.ServerStreams, payloads: c.capturePayloads(l)}
+		// NOTE: The context of the stream is done when the stream ends for any reason, so the goroutine does not outlive the stream.
+		//       Only the cancellation or the deadline of ctx is logged here, and the other endings are left to RecvMsg.
+		go func() {
+			select {
+			case <-ctx.Done():
+			case <-cs.Context().Done():
+			}
+			if err := ctx.Err(); err != nil {
+				s.once.Do(func() { s.log(status.FromContextError(err).Err()) })
+			}
+		}()
+
+		return s, nil
+	}
+}
+
+// requestLogger returns the logger with the fields of the RPC.
+func requestLogger(ctx context.Context, logger ilog.Logger, method string) ilog.Logger { //nolint:ireturn
+	e := logger.Ctx(ctx).String("method", method)
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		e = e.String("peer", p.Addr.String())
+	}
+	return e.Logger()
+}
+
+// rpcLog returns the log entry with the status code, the duration and the error of the RPC.
+func (c *config) rpcLog(l ilog.Logger, start time.Time, err error) ilog.LogEntry { //nolint:ireturn
+	e := l.String("code", status.Code(err).String()).Duration("duration", c.now().Sub(start))
+	if err != nil {
+		e = e.Err(err)
+	}
+	return e
+}
+
+// payload returns the message as compact JSON if it is proto.Message, otherwise as fmt.Sprintf("%+v").
+// The output of protojson is compacted, since it is deliberately unstable in whitespace.
+func payload(msg interface{}) string {
+	if m, ok := msg.(proto.Message); ok {
+		b, err := protojson.Marshal(m)
+		if err == nil {
+			buf := bytes.NewBuffer(make([]byte, 0, len(b)))
+			if err := json.Compact(buf, b); err == nil {
+				return buf.String()
+			}
+		}
+	}
+	return fmt.Sprintf("%+v", msg)
+}
+
+// serverStream is grpc.ServerStream whose context has the request-scoped logger.
+type serverStream struct {
+	grpc.ServerStream
+	ctx      context.Context //nolint:containedctx
+	logger   ilog.Logger
+	payloads bool
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.payloads {
+		s.logger.String("request", payload(m)).Debugf("received")
+	}
+	return err //nolint:wrapcheck
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if err == nil && s.payloads {
+		s.logger.String("response", payload(m)).Debugf("sent")
+	}
+	return err //nolint:wrapcheck
+}
+
+// clientStream is grpc.ClientStream that writes a log when the stream ends.
+type clientStream struct {
+	grpc.ClientStream
+	config *config
+	logger ilog.Logger
+	method string
+	start  time.Time
+	// serverStreams is false for unary and client-streaming RPCs, whose only response ends the stream.
+	serverStreams bool
+	payloads      bool
+	once          sync.Once
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil && s.payloads {
+		s.logger.String("request", payload(m)).Debugf("sent")
+	}
+	return err //nolint:wrapcheck
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		if s.payloads {
+			s.logger.String("response", payload(m)).Debugf("received")
+		}
+		if !s.serverStreams {
+			s.once.Do(func() { s.log(nil) })
+		}
+	case errors.Is(err, io.EOF):
+		s.once.Do(func() { s.log(nil) })
+	default:
+		s.once.Do(func() { s.log(err) })
+	}
+	return err //nolint:wrapcheck
+}
+
+func (s *clientStream) log(err error) {
+	code := status.Code(err)
+	s.config.rpcLog(s.logger, s.start, err).Logf(s.config.level(code), "%s %s", s.method, code)
+}
@@ -0,0 +1,368 @@
+package grpclog //nolint:testpackage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/kunitsucom/ilog.go"
+)
+
+// withNow sets the clock that advances by 1ms every time it is called.
+func withNow() Option {
+	return func(c *config) {
+		now := time.Date(2023, 8, 13, 4, 38, 39, 0, time.UTC)
+		c.now = func() time.Time {
+			now = now.Add(time.Millisecond)
+			return now
+		}
+	}
+}
+
+func newTestLogger(buf *bytes.Buffer) ilog.Logger { //nolint:ireturn
+	return ilog.NewBuilder(ilog.DebugLevel, ilog.NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").Build()
+}
+
+// writerFunc is io.Writer as a function.
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
+
+// testHealthServer is the health server that responds SERVING for "" and NotFound for the other services, and logs through the logger in the context.
+type testHealthServer struct {
+	healthpb.UnimplementedHealthServer
+}
+
+func (testHealthServer) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	ilog.FromContext(ctx).Infof("Check")
+	if req.GetService() != "" {
+		return nil, status.Error(codes.NotFound, "unknown service")
+	}
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
+
+func (testHealthServer) Watch(req *healthpb.HealthCheckRequest, stream healthpb.Health_WatchServer) error {
+	ilog.FromContext(stream.Context()).Infof("Watch")
+	if req.GetService() != "" {
+		return status.Error(codes.Internal, "internal error")
+	}
+	for _, s := range []healthpb.HealthCheckResponse_ServingStatus{healthpb.HealthCheckResponse_NOT_SERVING, healthpb.HealthCheckResponse_SERVING} {
+		if err := stream.Send(&healthpb.HealthCheckResponse{Status: s}); err != nil {
+			return err //nolint:wrapcheck
+		}
+	}
+	return nil
+}
+
+// testCollectMethod is the client-streaming method of testCollectServiceDesc, which responds the number of the received messages.
+const testCollectMethod = "/ilog.test.Test/Collect"
+
+//nolint:gochecknoglobals
+var testCollectServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ilog.test.Test",
+	HandlerType: (*interface{})(nil),
+	Streams: []grpc.StreamDesc{{
+		StreamName: "Collect",
+		Handler: func(_ interface{}, stream grpc.ServerStream) error {
+			ilog.FromContext(stream.Context()).Infof("Collect")
+			var n int64
+			for {
+				if err := stream.RecvMsg(new(wrapperspb.StringValue)); err != nil {
+					if errors.Is(err, io.EOF) {
+						return stream.SendMsg(wrapperspb.Int64(n)) //nolint:wrapcheck
+					}
+					return err //nolint:wrapcheck
+				}
+				n++
+			}
+		},
+		ClientStreams: true,
+	}},
+}
+
+// newTestClient starts the server with the server interceptors on bufconn, and returns the client with the client interceptors.
+func newTestClient(t *testing.T, serverLogger ilog.Logger, opts ...Option) (conn *grpc.ClientConn, cleanup func()) {
+	t.Helper()
+	const bufSize = 1 << 20
+	lis := bufconn.Listen(bufSize)
+	s := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(UnaryServerInterceptor(serverLogger, opts...)),
+		grpc.ChainStreamInterceptor(StreamServerInterceptor(serverLogger, opts...)),
+	)
+	healthpb.RegisterHealthServer(s, testHealthServer{})
+	s.RegisterService(&testCollectServiceDesc, struct{}{})
+	go func() { _ = s.Serve(lis) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(UnaryClientInterceptor(opts...)),
+		grpc.WithChainStreamInterceptor(StreamClientInterceptor(opts...)),
+	)
+	if err != nil {
+		t.Fatalf("❌: grpc.NewClient: %v", err)
+	}
+
+	return conn, func() {
+		_ = conn.Close()
+		s.Stop()
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+		serverBuf, clientBuf := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: serverBuf:\n%s", serverBuf)
+		defer t.Logf("ℹ️: clientBuf:\n%s", clientBuf)
+
+		conn, cleanup := newTestClient(t, newTestLogger(serverBuf), withNow())
+		defer cleanup()
+		client := healthpb.NewHealthClient(conn)
+		ctx := ilog.WithContext(context.Background(), newTestLogger(clientBuf))
+
+		if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+			t.Fatalf("❌: client.Check: %v", err)
+		}
+		if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown"}); status.Code(err) != codes.NotFound {
+			t.Fatalf("❌: client.Check: %v", err)
+		}
+
+		const fields = `"method":"/grpc.health.v1.Health/Check","peer":"bufconn"`
+		const expectedServer = `{"severity":"INFO","message":"Check",` + fields + `}` + "\n" +
+			`{"severity":"INFO","message":"/grpc.health.v1.Health/Check OK",` + fields + `,"code":"OK","duration":"1ms"}` + "\n" +
+			`{"severity":"INFO","message":"Check",` + fields + `}` + "\n" +
+			`{"severity":"INFO","message":"/grpc.health.v1.Health/Check NotFound",` + fields + `,"code":"NotFound","duration":"1ms","error":"rpc error: code = NotFound desc = unknown service"}` + "\n"
+		if actual := serverBuf.String(); expectedServer != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expectedServer, actual)
+		}
+		const expectedClient = `{"severity":"INFO","message":"/grpc.health.v1.Health/Check OK","method":"/grpc.health.v1.Health/Check","target":"passthrough:///bufnet","code":"OK","duration":"1ms"}` + "\n" +
+			`{"severity":"INFO","message":"/grpc.health.v1.Health/Check NotFound","method":"/grpc.health.v1.Health/Check","target":"passthrough:///bufnet","code":"NotFound","duration":"1ms","error":"rpc error: code = NotFound desc = unknown service"}` + "\n"
+		if actual := clientBuf.String(); expectedClient != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expectedClient, actual)
+		}
+	})
+
+	t.Run("success,WithPayloads", func(t *testing.T) {
+		t.Parallel()
+		serverBuf, clientBuf := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: serverBuf:\n%s", serverBuf)
+		defer t.Logf("ℹ️: clientBuf:\n%s", clientBuf)
+
+		conn, cleanup := newTestClient(t, newTestLogger(serverBuf).SetLevel(ilog.InfoLevel), withNow(), WithPayloads(true), WithLevel(func(codes.Code) ilog.Level { return ilog.DebugLevel }))
+		defer cleanup()
+		client := healthpb.NewHealthClient(conn)
+		ctx := ilog.WithContext(context.Background(), newTestLogger(clientBuf))
+
+		if _, err := client.Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+			t.Fatalf("❌: client.Check: %v", err)
+		}
+
+		// NOTE: the server logger is at InfoLevel, so neither the payloads nor the log at DebugLevel are written.
+		const expectedServer = `{"severity":"INFO","message":"Check","method":"/grpc.health.v1.Health/Check","peer":"bufconn"}` + "\n"
+		if actual := serverBuf.String(); expectedServer != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expectedServer, actual)
+		}
+		const expectedClient = `{"severity":"DEBUG","message":"/grpc.health.v1.Health/Check OK","method":"/grpc.health.v1.Health/Check","target":"passthrough:///bufnet","code":"OK","duration":"1ms","request":"{}","response":"{\"status\":\"SERVING\"}"}` + "\n"
+		if actual := clientBuf.String(); expectedClient != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expectedClient, actual)
+		}
+	})
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	t.Parallel()
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+		serverBuf, clientBuf := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: serverBuf:\n%s", serverBuf)
+		defer t.Logf("ℹ️: clientBuf:\n%s", clientBuf)
+
+		conn, cleanup := newTestClient(t, newTestLogger(serverBuf), withNow(), WithPayloads(true))
+		defer cleanup()
+		client := healthpb.NewHealthClient(conn)
+		ctx := ilog.WithContext(context.Background(), newTestLogger(clientBuf))
+
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: ""})
+		if err != nil {
+			t.Fatalf("❌: client.Watch: %v", err)
+		}
+		for {
+			if _, err := stream.Recv(); err != nil {
+				if !errors.Is(err, io.EOF) {
+					t.Fatalf("❌: stream.Recv: %v", err)
+				}
+				break
+			}
+		}
+
+		const fields = `"method":"/grpc.health.v1.Health/Watch","peer":"bufconn"`
+		const expectedServer = `{"severity":"DEBUG","message":"received",` + fields + `,"request":"{}"}` + "\n" +
+			`{"severity":"INFO","message":"Watch",` + fields + `}` + "\n" +
+			`{"severity":"DEBUG","message":"sent",` + fields + `,"response":"{\"status\":\"NOT_SERVING\"}"}` + "\n" +
+			`{"severity":"DEBUG","message":"sent",` + fields + `,"response":"{\"status\":\"SERVING\"}"}` + "\n" +
+			`{"severity":"INFO","message":"/grpc.health.v1.Health/Watch OK",` + fields + `,"code":"OK","duration":"1ms"}` + "\n"
+		if actual := serverBuf.String(); expectedServer != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expectedServer, actual)
+		}
+		const clientFields = `"method":"/grpc.health.v1.Health/Watch","target":"passthrough:///bufnet"`
+		const expectedClient = `{"severity":"DEBUG","message":"sent",` + clientFields + `,"request":"{}"}` + "\n" +
+			`{"severity":"DEBUG","message":"received",` + clientFields + `,"response":"{\"status\":\"NOT_SERVING\"}"}` + "\n" +
+			`{"severity":"DEBUG","message":"received",` + clientFields + `,"response":"{\"status\":\"SERVING\"}"}` + "\n" +
+			`{"severity":"INFO","message":"/grpc.health.v1.Health/Watch OK",` + clientFields + `,"code":"OK","duration":"1ms"}` + "\n"
+		if actual := clientBuf.String(); expectedClient != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expectedClient, actual)
+		}
+	})
+
+	t.Run("success,ClientStreams", func(t *testing.T) {
+		t.Parallel()
+		serverBuf, clientBuf := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: serverBuf:\n%s", serverBuf)
+		defer t.Logf("ℹ️: clientBuf:\n%s", clientBuf)
+
+		conn, cleanup := newTestClient(t, newTestLogger(serverBuf), withNow())
+		defer cleanup()
+		ctx := ilog.WithContext(context.Background(), newTestLogger(clientBuf))
+
+		stream, err := conn.NewStream(ctx, &testCollectServiceDesc.Streams[0], testCollectMethod)
+		if err != nil {
+			t.Fatalf("❌: conn.NewStream: %v", err)
+		}
+		for _, v := range []string{"a", "b"} {
+			if err := stream.SendMsg(wrapperspb.String(v)); err != nil {
+				t.Fatalf("❌: stream.SendMsg: %v", err)
+			}
+		}
+		// NOTE: CloseSend and RecvMsg are what CloseAndRecv of the generated code calls, and RecvMsg returns nil for the successful RPC.
+		if err := stream.CloseSend(); err != nil {
+			t.Fatalf("❌: stream.CloseSend: %v", err)
+		}
+		resp := new(wrapperspb.Int64Value)
+		if err := stream.RecvMsg(resp); err != nil {
+			t.Fatalf("❌: stream.RecvMsg: %v", err)
+		}
+		if expected, actual := int64(2), resp.GetValue(); expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+
+		const fields = `"method":"/ilog.test.Test/Collect","peer":"bufconn"`
+		const expectedServer = `{"severity":"INFO","message":"Collect",` + fields + `}` + "\n" +
+			`{"severity":"INFO","message":"/ilog.test.Test/Collect OK",` + fields + `,"code":"OK","duration":"1ms"}` + "\n"
+		if actual := serverBuf.String(); expectedServer != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expectedServer, actual)
+		}
+		const expectedClient = `{"severity":"INFO","message":"/ilog.test.Test/Collect OK","method":"/ilog.test.Test/Collect","target":"passthrough:///bufnet","code":"OK","duration":"1ms"}` + "\n"
+		if actual := clientBuf.String(); expectedClient != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expectedClient, actual)
+		}
+	})
+
+	t.Run("success,Cancel", func(t *testing.T) {
+		t.Parallel()
+		serverBuf, clientBuf := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: serverBuf:\n%s", serverBuf)
+
+		conn, cleanup := newTestClient(t, newTestLogger(serverBuf), withNow())
+		defer cleanup()
+		client := healthpb.NewHealthClient(conn)
+		// NOTE: The client log is written by the goroutine of the interceptor, so the test waits for it through the channel.
+		var mu sync.Mutex
+		written := make(chan struct{}, 1)
+		w := writerFunc(func(p []byte) (int, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			defer func() {
+				select {
+				case written <- struct{}{}:
+				default:
+				}
+			}()
+			return clientBuf.Write(p)
+		})
+		ctx, cancel := context.WithCancel(ilog.WithContext(context.Background(), ilog.NewBuilder(ilog.DebugLevel, w).SetTimestampKey("").SetCallerKey("").Build()))
+		defer cancel()
+
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: ""})
+		if err != nil {
+			t.Fatalf("❌: client.Watch: %v", err)
+		}
+		if _, err := stream.Recv(); err != nil {
+			t.Fatalf("❌: stream.Recv: %v", err)
+		}
+		// NOTE: The client cancels the RPC without receiving the messages until the end, so RecvMsg never reports the end of the stream.
+		cancel()
+
+		select {
+		case <-written:
+		case <-time.After(time.Second):
+			t.Fatalf("❌: the client log is not written")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		const expectedClient = `{"severity":"INFO","message":"/grpc.health.v1.Health/Watch Canceled","method":"/grpc.health.v1.Health/Watch","target":"passthrough:///bufnet","code":"Canceled","duration":"1ms","error":"rpc error: code = Canceled desc = context canceled"}` + "\n"
+		if actual := clientBuf.String(); expectedClient != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expectedClient, actual)
+		}
+	})
+
+	t.Run("failure,", func(t *testing.T) {
+		t.Parallel()
+		serverBuf, clientBuf := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: serverBuf:\n%s", serverBuf)
+		defer t.Logf("ℹ️: clientBuf:\n%s", clientBuf)
+
+		conn, cleanup := newTestClient(t, newTestLogger(serverBuf), withNow())
+		defer cleanup()
+		client := healthpb.NewHealthClient(conn)
+		ctx := ilog.WithContext(context.Background(), newTestLogger(clientBuf))
+
+		stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "unknown"})
+		if err != nil {
+			t.Fatalf("❌: client.Watch: %v", err)
+		}
+		if _, err := stream.Recv(); status.Code(err) != codes.Internal {
+			t.Fatalf("❌: stream.Recv: %v", err)
+		}
+		_, _ = stream.Recv()
+
+		const expectedServer = `{"severity":"INFO","message":"Watch","method":"/grpc.health.v1.Health/Watch","peer":"bufconn"}` + "\n" +
+			`{"severity":"ERROR","message":"/grpc.health.v1.Health/Watch Internal","method":"/grpc.health.v1.Health/Watch","peer":"bufconn","code":"Internal","duration":"1ms","error":"rpc error: code = Internal desc = internal error"}` + "\n"
+		if actual := serverBuf.String(); expectedServer != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expectedServer, actual)
+		}
+		const expectedClient = `{"severity":"ERROR","message":"/grpc.health.v1.Health/Watch Internal","method":"/grpc.health.v1.Health/Watch","target":"passthrough:///bufnet","code":"Internal","duration":"1ms","error":"rpc error: code = Internal desc = internal error"}` + "\n"
+		if actual := clientBuf.String(); expectedClient != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expectedClient, actual)
+		}
+	})
+}
+
+func TestLevelByCode(t *testing.T) {
+	t.Parallel()
+	for code, expected := range map[codes.Code]ilog.Level{
+		codes.OK:               ilog.InfoLevel,
+		codes.NotFound:         ilog.InfoLevel,
+		codes.DeadlineExceeded: ilog.WarnLevel,
+		codes.Internal:         ilog.ErrorLevel,
+		codes.Unknown:          ilog.ErrorLevel,
+	} {
+		if actual := LevelByCode(code); expected != actual {
+			t.Errorf("❌: %s: expected(%s) != actual(%s)", code, expected, actual)
+		}
+	}
+}
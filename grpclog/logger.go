@@ -0,0 +1,108 @@
+package grpclog
+
+import (
+	"fmt"
+	"strings"
+
+	grpclogv2 "google.golang.org/grpc/grpclog"
+
+	"github.com/kunitsucom/ilog.go"
+)
+
+type loggerV2 struct {
+	logger    ilog.Logger
+	verbosity int
+}
+
+var _ grpclogv2.DepthLoggerV2 = (*loggerV2)(nil)
+
+// NewLoggerV2 returns grpclog.LoggerV2 that writes the internal logs of gRPC through the logger.
+// It also implements grpclog.DepthLoggerV2, which the components of gRPC log through, so that the caller of the log is the function in gRPC that writes it.
+// V(l) reports true if l is less than or equal to verbosity, as GRPC_GO_LOG_VERBOSITY_LEVEL does for the default logger of gRPC.
+//
+//	grpclog.SetLoggerV2(ilogGrpclog.NewLoggerV2(l.Named("grpc"), 0))
+//
+// Fatal, Fatalln, Fatalf and FatalDepth exit through ilog.Exit after logging, like Logger.Fatalf.
+func NewLoggerV2(logger ilog.Logger, verbosity int) grpclogv2.LoggerV2 { //nolint:ireturn
+	// NOTE: skip the method of loggerV2 and the function of grpclog that calls it, such as grpclog.Info or grpclog.InfoDepth,
+	// as the logger in google.golang.org/grpc/grpclog/glogger does.
+	const skip = 2
+	return &loggerV2{
+		logger:    logger.Copy().AddCallerSkip(skip),
+		verbosity: verbosity,
+	}
+}
+
+func (l *loggerV2) Info(args ...interface{}) {
+	l.logger.Infof("%s", fmt.Sprint(args...))
+}
+
+func (l *loggerV2) Infoln(args ...interface{}) {
+	l.logger.Infof("%s", sprintln(args...))
+}
+
+func (l *loggerV2) Infof(format string, args ...interface{}) {
+	l.logger.Infof(format, args...)
+}
+
+func (l *loggerV2) Warning(args ...interface{}) {
+	l.logger.Warnf("%s", fmt.Sprint(args...))
+}
+
+func (l *loggerV2) Warningln(args ...interface{}) {
+	l.logger.Warnf("%s", sprintln(args...))
+}
+
+func (l *loggerV2) Warningf(format string, args ...interface{}) {
+	l.logger.Warnf(format, args...)
+}
+
+func (l *loggerV2) Error(args ...interface{}) {
+	l.logger.Errorf("%s", fmt.Sprint(args...))
+}
+
+func (l *loggerV2) Errorln(args ...interface{}) {
+	l.logger.Errorf("%s", sprintln(args...))
+}
+
+func (l *loggerV2) Errorf(format string, args ...interface{}) {
+	l.logger.Errorf(format, args...)
+}
+
+func (l *loggerV2) Fatal(args ...interface{}) {
+	l.logger.Fatalf("%s", fmt.Sprint(args...))
+}
+
+func (l *loggerV2) Fatalln(args ...interface{}) {
+	l.logger.Fatalf("%s", sprintln(args...))
+}
+
+func (l *loggerV2) Fatalf(format string, args ...interface{}) {
+	l.logger.Fatalf(format, args...)
+}
+
+// InfoDepth is called by grpclog.InfoDepth, and depth is the number of the frames to skip above it.
+func (l *loggerV2) InfoDepth(depth int, args ...interface{}) {
+	l.logger.AddCallerSkip(depth).Infof("%s", sprintln(args...))
+}
+
+func (l *loggerV2) WarningDepth(depth int, args ...interface{}) {
+	l.logger.AddCallerSkip(depth).Warnf("%s", sprintln(args...))
+}
+
+func (l *loggerV2) ErrorDepth(depth int, args ...interface{}) {
+	l.logger.AddCallerSkip(depth).Errorf("%s", sprintln(args...))
+}
+
+func (l *loggerV2) FatalDepth(depth int, args ...interface{}) {
+	l.logger.AddCallerSkip(depth).Fatalf("%s", sprintln(args...))
+}
+
+func (l *loggerV2) V(level int) bool {
+	return level <= l.verbosity
+}
+
+// sprintln returns the operands formatted like fmt.Sprintln without the trailing newline.
+func sprintln(args ...interface{}) string {
+	return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+}
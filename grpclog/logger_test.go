@@ -0,0 +1,78 @@
+package grpclog //nolint:testpackage
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"testing"
+
+	grpclogv2 "google.golang.org/grpc/grpclog"
+
+	"github.com/kunitsucom/ilog.go"
+)
+
+// testFatal calls the logger like grpclog.Fatal, Fatalln, Fatalf and FatalDepth, which cannot be called in tests since they call os.Exit.
+func testFatal(l grpclogv2.LoggerV2) {
+	l.Fatal("Fatal")
+	l.Fatalln("Fatalln")
+	l.Fatalf("Fatalf: %s", "arg")
+	l.(grpclogv2.DepthLoggerV2).FatalDepth(0, "FatalDepth") //nolint:forcetypeassert
+}
+
+//nolint:paralleltest
+func TestNewLoggerV2(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	defer t.Logf("ℹ️: buf:\n%s", buf)
+	var codes []int
+	defer ilog.SetExitFunc(func(code int) { codes = append(codes, code) })()
+
+	l := NewLoggerV2(ilog.NewBuilder(ilog.DebugLevel, ilog.NewSyncWriter(buf)).SetTimestampKey("").Build(), 1)
+	grpclogv2.SetLoggerV2(l)
+	defer grpclogv2.SetLoggerV2(grpclogv2.NewLoggerV2(ioutil.Discard, ioutil.Discard, ioutil.Discard))
+
+	grpclogv2.Info("Info", 1)
+	grpclogv2.Infoln("Infoln", 1)
+	grpclogv2.Infof("Infof: %d", 1)
+	grpclogv2.Warning("Warning")
+	grpclogv2.Warningln("Warningln")
+	grpclogv2.Warningf("Warningf: %s", "arg")
+	grpclogv2.Error("Error")
+	grpclogv2.Errorln("Errorln")
+	grpclogv2.Errorf("Errorf: %s", "arg")
+	testFatal(l)
+	// NOTE: the components of gRPC log through grpclog.InfoDepth and so on.
+	c := grpclogv2.Component("test")
+	c.Info("Info")
+	c.Infof("Infof: %d", 1)
+	c.Warning("Warning")
+	c.Errorln("Errorln")
+
+	expected := regexp.MustCompilePOSIX(`^{"severity":"INFO","caller":"grpclog/logger_test\.go:[0-9]+","message":"Info1"}
+{"severity":"INFO","caller":"grpclog/logger_test\.go:[0-9]+","message":"Infoln 1"}
+{"severity":"INFO","caller":"grpclog/logger_test\.go:[0-9]+","message":"Infof: 1"}
+{"severity":"WARN","caller":"grpclog/logger_test\.go:[0-9]+","message":"Warning"}
+{"severity":"WARN","caller":"grpclog/logger_test\.go:[0-9]+","message":"Warningln"}
+{"severity":"WARN","caller":"grpclog/logger_test\.go:[0-9]+","message":"Warningf: arg"}
+{"severity":"ERROR","caller":"grpclog/logger_test\.go:[0-9]+","message":"Error"}
+{"severity":"ERROR","caller":"grpclog/logger_test\.go:[0-9]+","message":"Errorln"}
+{"severity":"ERROR","caller":"grpclog/logger_test\.go:[0-9]+","message":"Errorf: arg"}
+{"severity":"FATAL","caller":"grpclog/logger_test\.go:[0-9]+","message":"Fatal"}
+{"severity":"FATAL","caller":"grpclog/logger_test\.go:[0-9]+","message":"Fatalln"}
+{"severity":"FATAL","caller":"grpclog/logger_test\.go:[0-9]+","message":"Fatalf: arg"}
+{"severity":"FATAL","caller":"grpclog/logger_test\.go:[0-9]+","message":"FatalDepth"}
+{"severity":"INFO","caller":"grpclog/logger_test\.go:[0-9]+","message":"\[test\] Info"}
+{"severity":"INFO","caller":"grpclog/logger_test\.go:[0-9]+","message":"\[test\] Infof: 1"}
+{"severity":"WARN","caller":"grpclog/logger_test\.go:[0-9]+","message":"\[test\] Warning"}
+{"severity":"ERROR","caller":"grpclog/logger_test\.go:[0-9]+","message":"\[test\] Errorln"}
+$`)
+	if !expected.Match(buf.Bytes()) {
+		t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+	}
+	if expected, actual := "[1 1 1 1]", fmt.Sprint(codes); expected != actual {
+		t.Errorf("❌: expected(%s) != actual(%s)", expected, actual)
+	}
+	if !l.V(1) || l.V(2) {
+		t.Errorf("❌: V: expected V(1) && !V(2)")
+	}
+}
@@ -0,0 +1,83 @@
+package ilog
+
+import (
+	"sync"
+	"time"
+)
+
+// Hook is the interface that is called for every log entry written by the logger.
+type Hook interface {
+	Run(entry HookEntry)
+}
+
+// HookFunc is an adapter to allow the use of ordinary functions as Hook.
+type HookFunc func(entry HookEntry)
+
+// Run calls f(entry).
+func (f HookFunc) Run(entry HookEntry) {
+	f(entry)
+}
+
+// HookEntry is the log entry passed to Hook.
+type HookEntry struct {
+	Level      Level
+	Message    string
+	Timestamp  time.Time
+	CallerFile string
+	CallerLine int
+	// Fields is the fields of the log entry including the logger name. Nested objects and arrays are map[string]interface{} and []interface{}.
+	// The default implementation passes the values as they are added, e.g. time.Duration and error, while the implementations for the other loggers pass the values decoded from their output.
+	Fields map[string]interface{}
+}
+
+// AsyncHook is the Hook that runs another Hook in a separate goroutine.
+type AsyncHook struct {
+	hook    Hook
+	mu      sync.RWMutex
+	closed  bool
+	entries chan HookEntry
+	done    chan struct{}
+}
+
+// NewAsyncHook returns a new AsyncHook that runs the hook in a separate goroutine.
+// Up to size entries are buffered, and Run blocks while the buffer is full.
+// Close must be called to run the buffered entries and stop the goroutine.
+func NewAsyncHook(hook Hook, size int) *AsyncHook {
+	h := &AsyncHook{
+		hook:    hook,
+		entries: make(chan HookEntry, size),
+		done:    make(chan struct{}),
+	}
+
+	go func() {
+		defer close(h.done)
+		for entry := range h.entries {
+			h.hook.Run(entry)
+		}
+	}()
+
+	return h
+}
+
+// Run passes the entry to the goroutine. After Close is called, the entry is discarded.
+func (h *AsyncHook) Run(entry HookEntry) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.closed {
+		return
+	}
+	h.entries <- entry
+}
+
+// Close waits for the buffered entries to be run, and stops the goroutine.
+func (h *AsyncHook) Close() error {
+	h.mu.Lock()
+	if !h.closed {
+		h.closed = true
+		close(h.entries)
+	}
+	h.mu.Unlock()
+
+	<-h.done
+	return nil
+}
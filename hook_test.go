@@ -0,0 +1,30 @@
+package ilog //nolint:testpackage
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAsyncHook(t *testing.T) {
+	t.Parallel()
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+		var messages []string
+		h := NewAsyncHook(HookFunc(func(entry HookEntry) { messages = append(messages, entry.Message) }), 1)
+
+		for _, message := range []string{"a", "b", "c"} {
+			h.Run(HookEntry{Message: message})
+		}
+		if err := h.Close(); err != nil {
+			t.Errorf("❌: err != nil: %v", err)
+		}
+		h.Run(HookEntry{Message: "closed"})
+		if err := h.Close(); err != nil {
+			t.Errorf("❌: err != nil: %v", err)
+		}
+
+		if expected, actual := "[a b c]", fmt.Sprint(messages); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+}
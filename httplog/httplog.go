@@ -0,0 +1,215 @@
+// Package httplog provides net/http middleware which injects a request-scoped ilog.Logger and writes access logs.
+package httplog
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kunitsucom/ilog.go"
+)
+
+// ErrHijackNotSupported is the error returned by Hijack if the underlying http.ResponseWriter does not implement http.Hijacker.
+var ErrHijackNotSupported = errors.New("httplog: http.Hijacker not supported")
+
+const (
+	// traceparentHeader is the header of W3C Trace Context, such as "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01".
+	//
+	// cf. https://www.w3.org/TR/trace-context/#traceparent-header
+	traceparentHeader = "traceparent"
+	traceIDLength     = 32
+)
+
+// Option is the option of NewMiddleware.
+type Option func(c *config)
+
+type config struct {
+	requestIDHeader string
+	traceID         func(r *http.Request) string
+	level           func(status int) ilog.Level
+	now             func() time.Time
+}
+
+// WithRequestIDHeader sets the header whose value is added to the request-scoped logger as "request_id".
+// If empty, the request ID is not added.
+// Default is "X-Request-Id".
+func WithRequestIDHeader(header string) Option {
+	return func(c *config) {
+		c.requestIDHeader = header
+	}
+}
+
+// WithTraceID sets the function that returns the trace ID of the request, which is added to the request-scoped logger as "trace_id".
+// If the function returns an empty string, the trace ID is not added.
+// Default returns the trace ID in the traceparent header of W3C Trace Context.
+func WithTraceID(traceID func(r *http.Request) string) Option {
+	return func(c *config) {
+		c.traceID = traceID
+	}
+}
+
+// WithLevel sets the function that returns the level of the access log from the status code.
+// Default is LevelByStatus.
+func WithLevel(level func(status int) ilog.Level) Option {
+	return func(c *config) {
+		c.level = level
+	}
+}
+
+// LevelByStatus returns ErrorLevel for 5xx, WarnLevel for 4xx, and InfoLevel for the other status codes.
+func LevelByStatus(status int) ilog.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return ilog.ErrorLevel
+	case status >= http.StatusBadRequest:
+		return ilog.WarnLevel
+	default:
+		return ilog.InfoLevel
+	}
+}
+
+// TraceparentTraceID returns the trace ID in the traceparent header of W3C Trace Context, or an empty string if the header is invalid.
+func TraceparentTraceID(r *http.Request) string {
+	// NOTE: version "-" trace-id "-" parent-id "-" trace-flags
+	parts := strings.Split(r.Header.Get(traceparentHeader), "-")
+	const minParts = 4
+	if len(parts) < minParts || len(parts[1]) != traceIDLength || strings.Trim(parts[1], "0") == "" {
+		return ""
+	}
+	for _, c := range parts[1] {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return ""
+		}
+	}
+	return parts[1]
+}
+
+// NewMiddleware returns net/http middleware that attaches the logger with the fields of the request to the request context, and writes an access log per request.
+//
+// The request-scoped logger, which can be retrieved by ilog.FromContext, has the following fields:
+//
+//	method, path, remote_addr, request_id (if the request has the request ID header), trace_id (if the request has a trace ID)
+//
+// The access log is written through the request-scoped logger with the level chosen by the status code, and has the following fields:
+//
+//	status, bytes, latency
+//
+// If the handler panics, the middleware recovers it, responds 500 Internal Server Error if the header has not been written yet, and writes the access log with the panic and the stack trace.
+// http.ErrAbortHandler is re-panicked after the access log is written, so that net/http aborts the response.
+func NewMiddleware(logger ilog.Logger, opts ...Option) func(next http.Handler) http.Handler {
+	c := &config{
+		requestIDHeader: "X-Request-Id",
+		traceID:         TraceparentTraceID,
+		level:           LevelByStatus,
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := c.now()
+			l := c.requestLogger(logger, r)
+			rw := &responseWriter{ResponseWriter: w}
+
+			defer func() {
+				if p := recover(); p != nil {
+					if !rw.wroteHeader {
+						http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+					}
+					c.accessLog(l, rw, start).Any("panic", p).Stack("stack").Errorf("%s %s %d", r.Method, r.URL.Path, rw.status)
+					if p == http.ErrAbortHandler { //nolint:errorlint,goerr113
+						panic(p)
+					}
+					return
+				}
+				if !rw.wroteHeader {
+					rw.status = http.StatusOK
+				}
+				c.accessLog(l, rw, start).Logf(c.level(rw.status), "%s %s %d", r.Method, r.URL.Path, rw.status)
+			}()
+
+			next.ServeHTTP(rw, r.WithContext(ilog.WithContext(r.Context(), l)))
+		})
+	}
+}
+
+func (c *config) requestLogger(logger ilog.Logger, r *http.Request) ilog.Logger { //nolint:ireturn
+	e := logger.Ctx(r.Context()).
+		String("method", r.Method).
+		String("path", r.URL.Path).
+		String("remote_addr", r.RemoteAddr)
+	if c.requestIDHeader != "" {
+		if requestID := r.Header.Get(c.requestIDHeader); requestID != "" {
+			e = e.String("request_id", requestID)
+		}
+	}
+	if traceID := c.traceID(r); traceID != "" {
+		e = e.String("trace_id", traceID)
+	}
+	return e.Logger()
+}
+
+func (c *config) accessLog(l ilog.Logger, rw *responseWriter, start time.Time) ilog.LogEntry { //nolint:ireturn
+	return l.Int("status", rw.status).
+		Int64("bytes", rw.bytes).
+		Duration("latency", c.now().Sub(start))
+}
+
+// responseWriter is http.ResponseWriter that records the status code and the number of bytes written.
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+// WriteHeader records the status code of the first final response.
+// Informational responses (1xx) such as 103 Early Hints are written but not recorded, since the final response follows them.
+// 101 Switching Protocols is recorded, since it is the final response of the connection.
+func (w *responseWriter) WriteHeader(status int) {
+	informational := status >= 100 && status < 200 && status != http.StatusSwitchingProtocols
+	if !w.wroteHeader && !informational {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += int64(n)
+	return n, err //nolint:wrapcheck
+}
+
+// Flush implements http.Flusher if the underlying http.ResponseWriter implements it.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		if !w.wroteHeader {
+			w.WriteHeader(http.StatusOK)
+		}
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker if the underlying http.ResponseWriter implements it, otherwise it returns ErrHijackNotSupported.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("%T: %w", w.ResponseWriter, ErrHijackNotSupported)
+	}
+	return h.Hijack() //nolint:wrapcheck
+}
+
+// Unwrap returns the underlying http.ResponseWriter for http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,231 @@
+package httplog //nolint:testpackage
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kunitsucom/ilog.go"
+)
+
+// withNow sets the clock that advances by 1ms every time it is called.
+func withNow() Option {
+	return func(c *config) {
+		now := time.Date(2023, 8, 13, 4, 38, 39, 0, time.UTC)
+		c.now = func() time.Time {
+			now = now.Add(time.Millisecond)
+			return now
+		}
+	}
+}
+
+func newTestLogger(buf *bytes.Buffer) ilog.Logger { //nolint:ireturn
+	return ilog.NewBuilder(ilog.DebugLevel, ilog.NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").Build()
+}
+
+func TestNewMiddleware(t *testing.T) {
+	t.Parallel()
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		h := NewMiddleware(newTestLogger(buf), withNow())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ilog.FromContext(r.Context()).Infof("handled")
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, "created")
+		}))
+		r := httptest.NewRequest(http.MethodPost, "/users?name=gopher", nil)
+		r.Header.Set("X-Request-Id", "req-1")
+		r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		if expected, actual := http.StatusCreated, w.Code; expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+		const fields = `"method":"POST","path":"/users","remote_addr":"192.0.2.1:1234","request_id":"req-1","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`
+		const expected = `{"severity":"INFO","message":"handled",` + fields + `}` + "\n" +
+			`{"severity":"INFO","message":"POST /users 201",` + fields + `,"status":201,"bytes":7,"latency":"1ms"}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,level", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		h := NewMiddleware(newTestLogger(buf), withNow(), WithRequestIDHeader(""), WithTraceID(func(*http.Request) string { return "" }))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/ok":
+			case "/not-found":
+				http.NotFound(w, r)
+			default:
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		for _, path := range []string{"/ok", "/not-found", "/unavailable"} {
+			r := httptest.NewRequest(http.MethodGet, path, nil)
+			r.Header.Set("X-Request-Id", "req-1")
+			r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+			h.ServeHTTP(httptest.NewRecorder(), r)
+		}
+
+		const expected = `{"severity":"INFO","message":"GET /ok 200","method":"GET","path":"/ok","remote_addr":"192.0.2.1:1234","status":200,"bytes":0,"latency":"1ms"}` + "\n" +
+			`{"severity":"WARN","message":"GET /not-found 404","method":"GET","path":"/not-found","remote_addr":"192.0.2.1:1234","status":404,"bytes":19,"latency":"1ms"}` + "\n" +
+			`{"severity":"ERROR","message":"GET /unavailable 503","method":"GET","path":"/unavailable","remote_addr":"192.0.2.1:1234","status":503,"bytes":0,"latency":"1ms"}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,WithLevel", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		h := NewMiddleware(newTestLogger(buf), withNow(), WithLevel(func(int) ilog.Level { return ilog.DebugLevel }))(http.NotFoundHandler())
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		const expected = `{"severity":"DEBUG","message":"GET / 404","method":"GET","path":"/","remote_addr":"192.0.2.1:1234","status":404,"bytes":19,"latency":"1ms"}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,panic", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		h := NewMiddleware(newTestLogger(buf), withNow())(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+			panic("boom")
+		}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if expected, actual := http.StatusInternalServerError, w.Code; expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+		expected := regexp.MustCompile(`^{"severity":"ERROR","message":"GET / 500","method":"GET","path":"/","remote_addr":"192\.0\.2\.1:1234","status":500,"bytes":22,"latency":"1ms","panic":"boom","stack":"[^"]+httplog_test\.go:[0-9]+[^"]+"}` + "\n$")
+		if !expected.Match(buf.Bytes()) {
+			t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+		}
+	})
+
+	t.Run("success,ErrAbortHandler", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		h := NewMiddleware(newTestLogger(buf), withNow())(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			panic(http.ErrAbortHandler)
+		}))
+		func() {
+			defer func() {
+				if expected, actual := http.ErrAbortHandler, recover(); expected != actual {
+					t.Errorf("❌: expected(%v) != actual(%v)", expected, actual)
+				}
+			}()
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		}()
+
+		if expected, actual := `{"severity":"ERROR","message":"GET / 200","method":"GET","path":"/","remote_addr":"192.0.2.1:1234","status":200,"bytes":0,"latency":"1ms","panic":"net/http: abort Handler"`, buf.String(); !strings.HasPrefix(actual, expected) {
+			t.Errorf("❌: expected(%q) is not prefix of actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,Informational", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		mw := NewMiddleware(newTestLogger(buf), withNow())
+		for _, statuses := range [][]int{
+			{http.StatusEarlyHints, http.StatusNoContent},
+			{http.StatusContinue, http.StatusEarlyHints},
+			{http.StatusSwitchingProtocols},
+		} {
+			statuses := statuses
+			h := mw(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				for _, status := range statuses {
+					w.WriteHeader(status)
+				}
+			}))
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		}
+
+		const fields = `"method":"GET","path":"/","remote_addr":"192.0.2.1:1234"`
+		const expected = `{"severity":"INFO","message":"GET / 204",` + fields + `,"status":204,"bytes":0,"latency":"1ms"}` + "\n" +
+			`{"severity":"INFO","message":"GET / 200",` + fields + `,"status":200,"bytes":0,"latency":"1ms"}` + "\n" +
+			`{"severity":"INFO","message":"GET / 101",` + fields + `,"status":101,"bytes":0,"latency":"1ms"}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,Flush,Hijack", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		var err error
+		h := NewMiddleware(newTestLogger(buf), withNow())(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.(http.Flusher).Flush()
+			_, _, err = w.(http.Hijacker).Hijack()
+		}))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		if !w.Flushed {
+			t.Errorf("❌: !w.Flushed")
+		}
+		if !errors.Is(err, ErrHijackNotSupported) {
+			t.Errorf("❌: !errors.Is(err, ErrHijackNotSupported): %v", err)
+		}
+	})
+}
+
+func TestTraceparentTraceID(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		traceparent string
+		expected    string
+	}{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"", ""},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7", ""},
+		{"00-4bf92f3577b34da6a3ce929d0e0e473-00f067aa0ba902b7-01", ""},
+		{"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", ""},
+		{"00-00000000000000000000000000000000-00f067aa0ba902b7-01", ""},
+	} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("traceparent", tt.traceparent)
+		if actual := TraceparentTraceID(r); tt.expected != actual {
+			t.Errorf("❌: %q: expected(%q) != actual(%q)", tt.traceparent, tt.expected, actual)
+		}
+	}
+}
+
+func TestLevelByStatus(t *testing.T) {
+	t.Parallel()
+	for status, expected := range map[int]ilog.Level{
+		http.StatusOK:                  ilog.InfoLevel,
+		http.StatusFound:               ilog.InfoLevel,
+		http.StatusBadRequest:          ilog.WarnLevel,
+		http.StatusInternalServerError: ilog.ErrorLevel,
+	} {
+		if actual := LevelByStatus(status); expected != actual {
+			t.Errorf("❌: %d: expected(%s) != actual(%s)", status, expected, actual)
+		}
+	}
+}
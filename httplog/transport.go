@@ -0,0 +1,213 @@
+package httplog
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/kunitsucom/ilog.go"
+)
+
+// redacted is the value that replaces the redacted query values and password of URLs.
+const redacted = "REDACTED"
+
+// TransportOption is the option of NewTransport.
+type TransportOption func(t *transport)
+
+// WithRedactQuery sets the function that reports whether the value of the query parameter is redacted in the logged URL.
+// Default redacts all the values, since they may contain credentials such as API keys. See also RedactQueryKeys.
+func WithRedactQuery(redact func(key string) bool) TransportOption {
+	return func(t *transport) {
+		t.redactQuery = redact
+	}
+}
+
+// RedactQueryKeys returns the function for WithRedactQuery that redacts only the values of the keys.
+func RedactQueryKeys(keys ...string) func(key string) bool {
+	m := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		m[key] = true
+	}
+	return func(key string) bool {
+		return m[key]
+	}
+}
+
+// WithBodyCapture sets the maximum number of bytes of the request and response bodies to log as "request_body" and "response_body".
+// The bodies are captured only if the level of the logger is DebugLevel or lower, so that they are not read in production.
+// The response body is captured as the caller reads it, so a streaming response such as Server-Sent Events is not blocked,
+// and the log is written when the response body is read to the end or closed.
+// The response body of 101 Switching Protocols is not captured, since it is the upgraded connection.
+// Default is 0, so the bodies are not captured.
+func WithBodyCapture(limit int) TransportOption {
+	return func(t *transport) {
+		t.bodyLimit = limit
+	}
+}
+
+// WithTransportLevel sets the function that returns the level of the log from the status code.
+// The log of a request that fails without response is always written at ErrorLevel.
+// Default is LevelByStatus.
+func WithTransportLevel(level func(status int) ilog.Level) TransportOption {
+	return func(t *transport) {
+		t.level = level
+	}
+}
+
+type transport struct {
+	next        http.RoundTripper
+	redactQuery func(key string) bool
+	bodyLimit   int
+	level       func(status int) ilog.Level
+	now         func() time.Time
+}
+
+// NewTransport returns http.RoundTripper that sends requests through next, and logs each request through the logger that ilog.FromContext returns for the request context.
+// If next is nil, http.DefaultTransport is used.
+//
+// The log has the following fields:
+//
+//	method, url (with the query values redacted), status (if the response is received), latency, error (if the request fails)
+func NewTransport(next http.RoundTripper, opts ...TransportOption) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	t := &transport{
+		next:        next,
+		redactQuery: func(string) bool { return true },
+		level:       LevelByStatus,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	l := ilog.FromContext(req.Context())
+	capture := t.bodyLimit > 0 && l.Level() <= ilog.DebugLevel
+
+	var requestBody []byte
+	if capture && req.Body != nil && req.Body != http.NoBody {
+		// NOTE: RoundTripper must not modify the request, so the body is replaced in a clone.
+		cloned := req.Clone(req.Context())
+		requestBody, cloned.Body = captureBody(req.Body, t.bodyLimit)
+		req = cloned
+	}
+
+	start := t.now()
+	resp, err := t.next.RoundTrip(req)
+	latency := t.now().Sub(start)
+
+	u := t.redactURL(req.URL)
+	if err != nil {
+		l.String("method", req.Method).String("url", u).Duration("latency", latency).Err(err).Errorf("%s %s: %v", req.Method, u, err)
+		return resp, err //nolint:wrapcheck
+	}
+
+	e := l.String("method", req.Method).String("url", u).Int("status", resp.StatusCode).Duration("latency", latency)
+	level := t.level(resp.StatusCode)
+	if !capture {
+		e.Logf(level, "%s %s %d", req.Method, u, resp.StatusCode)
+		return resp, nil
+	}
+
+	e = e.Bytes("request_body", requestBody)
+	if resp.StatusCode == http.StatusSwitchingProtocols {
+		// NOTE: the body of 101 Switching Protocols is io.ReadWriteCloser of the upgraded connection, so it must not be wrapped.
+		e.Logf(level, "%s %s %d", req.Method, u, resp.StatusCode)
+		return resp, nil
+	}
+	resp.Body = &captureReadCloser{
+		body:  resp.Body,
+		limit: t.bodyLimit,
+		done: func(responseBody []byte) {
+			e.Bytes("response_body", responseBody).Logf(level, "%s %s %d", req.Method, u, resp.StatusCode)
+		},
+	}
+
+	return resp, nil
+}
+
+// redactURL returns the URL whose query values and password are redacted.
+func (t *transport) redactURL(u *url.URL) string {
+	redactedURL := *u
+	if password, ok := u.User.Password(); ok && password != "" {
+		redactedURL.User = url.UserPassword(u.User.Username(), redacted)
+	}
+	if u.RawQuery != "" {
+		query := u.Query()
+		for key, values := range query {
+			if t.redactQuery(key) {
+				for i := range values {
+					values[i] = redacted
+				}
+			}
+		}
+		redactedURL.RawQuery = query.Encode()
+	}
+	return redactedURL.String()
+}
+
+// captureBody reads up to limit bytes from body, and returns them and the body that reads the whole content including them.
+func captureBody(body io.ReadCloser, limit int) ([]byte, io.ReadCloser) {
+	captured, _ := ioutil.ReadAll(io.LimitReader(body, int64(limit)))
+	return captured, &readCloser{Reader: io.MultiReader(bytes.NewReader(captured), body), Closer: body}
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// captureReadCloser is io.ReadCloser that captures up to limit bytes of body as the caller reads it.
+// It calls done with the captured bytes once when body returns an error such as io.EOF or is closed.
+type captureReadCloser struct {
+	body  io.ReadCloser
+	limit int
+	done  func(captured []byte)
+
+	mu       sync.Mutex
+	captured []byte
+	finished bool
+}
+
+func (c *captureReadCloser) Read(p []byte) (int, error) {
+	n, err := c.body.Read(p)
+	c.mu.Lock()
+	if rest := c.limit - len(c.captured); rest > 0 && !c.finished {
+		if n < rest {
+			rest = n
+		}
+		c.captured = append(c.captured, p[:rest]...)
+	}
+	c.mu.Unlock()
+	if err != nil {
+		c.finish()
+	}
+	return n, err //nolint:wrapcheck
+}
+
+func (c *captureReadCloser) Close() error {
+	err := c.body.Close()
+	c.finish()
+	return err //nolint:wrapcheck
+}
+
+func (c *captureReadCloser) finish() {
+	c.mu.Lock()
+	if c.finished {
+		c.mu.Unlock()
+		return
+	}
+	c.finished = true
+	captured := c.captured
+	c.mu.Unlock()
+
+	c.done(captured)
+}
@@ -3,6 +3,7 @@
 package ilog
 
 import (
+	"context"
 	"errors"
 	"time"
 )
@@ -14,10 +15,13 @@ var ErrLogEntryIsNotWritten = errors.New("ilog: log entry not written")
 type Level int8
 
 const (
+	TraceLevel Level = -16
 	DebugLevel Level = -8
 	InfoLevel  Level = 0
 	WarnLevel  Level = 8
 	ErrorLevel Level = 16
+	PanicLevel Level = 24
+	FatalLevel Level = 32
 )
 
 // Logger is the interface that has the basic logging methods.
@@ -30,6 +34,9 @@ type Logger interface {
 	AddCallerSkip(skip int) (copied Logger)
 	// Copy returns a copy of the logger.
 	Copy() (copied Logger)
+	// Named returns a copy of the logger whose name is the name appended to the name of the logger with ".", such as "db.pool".
+	// The name is output as a field, and it can be used to configure the level of the logger by LevelRegistry.
+	Named(name string) (copied Logger)
 
 	// common is the interface that has the common logging methods for both ilog.Logger and ilog.LogEntry.
 	common
@@ -53,7 +60,30 @@ type common interface {
 	Uint(key string, value uint) (entry LogEntry)
 	Uint32(key string, value uint32) (entry LogEntry)
 	Uint64(key string, value uint64) (entry LogEntry)
+	// Object adds a nested object field whose fields are added by the marshaler.
+	Object(key string, marshaler ObjectMarshaler) (entry LogEntry)
+	// Group opens a namespace. The fields added after Group are nested in an object with the specified name.
+	// The namespace is kept until the log entry is written, and it is also inherited by the logger returned by LogEntry.Logger.
+	Group(name string) (entry LogEntry)
+	// Array adds an array field whose elements are appended by the marshaler.
+	Array(key string, marshaler ArrayMarshaler) (entry LogEntry)
+	Bools(key string, values []bool) (entry LogEntry)
+	Durations(key string, values []time.Duration) (entry LogEntry)
+	Errs(key string, errs []error) (entry LogEntry)
+	Float64s(key string, values []float64) (entry LogEntry)
+	Ints(key string, values []int) (entry LogEntry)
+	Int64s(key string, values []int64) (entry LogEntry)
+	Strings(key string, values []string) (entry LogEntry)
+	Times(key string, values []time.Time) (entry LogEntry)
+	// Stack adds the stack trace of the current goroutine, starting from the caller of Stack.
+	Stack(key string) (entry LogEntry)
+	// Ctx adds the fields extracted from ctx by the ContextExtractors registered by RegisterContextExtractor.
+	Ctx(ctx context.Context) (entry LogEntry)
 
+	// Tracef logs a message at trace level.
+	// If the argument is one, it is treated 1st argument as a simple string.
+	// If the argument is more than one, it is treated 1st argument as a format string.
+	Tracef(format string, args ...interface{})
 	// Debugf logs a message at debug level.
 	// If the argument is one, it is treated 1st argument as a simple string.
 	// If the argument is more than one, it is treated 1st argument as a format string.
@@ -70,7 +100,16 @@ type common interface {
 	// If the argument is one, it is treated 1st argument as a simple string.
 	// If the argument is more than one, it is treated 1st argument as a format string.
 	Errorf(format string, args ...interface{})
+	// Panicf logs a message at panic level, and then panics with the message.
+	// If the argument is one, it is treated 1st argument as a simple string.
+	// If the argument is more than one, it is treated 1st argument as a format string.
+	Panicf(format string, args ...interface{})
+	// Fatalf logs a message at fatal level, and then calls the exit function set by SetExitFunc with 1, which is os.Exit by default.
+	// If the argument is one, it is treated 1st argument as a simple string.
+	// If the argument is more than one, it is treated 1st argument as a format string.
+	Fatalf(format string, args ...interface{})
 	// Logf logs a message at the specified level.
+	// Unlike Panicf and Fatalf, it neither panics nor exits even if the level is PanicLevel or FatalLevel.
 	// If the argument is one, it is treated 1st argument as a simple string.
 	// If the argument is more than one, it is treated 1st argument as a format string.
 	Logf(level Level, format string, args ...interface{})
@@ -80,6 +119,82 @@ type common interface {
 	Write(p []byte) (n int, err error)
 }
 
+// ObjectMarshaler is the interface implemented by types that can add themselves to a log entry as a nested object without reflection.
+type ObjectMarshaler interface {
+	MarshalLogObject(enc ObjectEncoder) error
+}
+
+// ObjectMarshalerFunc is an adapter to allow the use of ordinary functions as ObjectMarshaler.
+type ObjectMarshalerFunc func(enc ObjectEncoder) error
+
+// MarshalLogObject calls f(enc).
+func (f ObjectMarshalerFunc) MarshalLogObject(enc ObjectEncoder) error {
+	return f(enc)
+}
+
+// ObjectEncoder is the interface that has the methods for adding fields to a nested object.
+type ObjectEncoder interface {
+	AddAny(key string, value interface{})
+	AddBool(key string, value bool)
+	AddBytes(key string, value []byte)
+	AddDuration(key string, value time.Duration)
+	AddErr(key string, err error)
+	AddFloat32(key string, value float32)
+	AddFloat64(key string, value float64)
+	AddInt(key string, value int)
+	AddInt32(key string, value int32)
+	AddInt64(key string, value int64)
+	AddObject(key string, marshaler ObjectMarshaler)
+	AddString(key, value string)
+	AddTime(key string, value time.Time)
+	AddUint(key string, value uint)
+	AddUint32(key string, value uint32)
+	AddUint64(key string, value uint64)
+	AddArray(key string, marshaler ArrayMarshaler)
+	AddBools(key string, values []bool)
+	AddDurations(key string, values []time.Duration)
+	AddErrs(key string, errs []error)
+	AddFloat64s(key string, values []float64)
+	AddInts(key string, values []int)
+	AddInt64s(key string, values []int64)
+	AddStrings(key string, values []string)
+	AddTimes(key string, values []time.Time)
+}
+
+// ArrayMarshaler is the interface implemented by types that can add themselves to a log entry as an array without reflection.
+type ArrayMarshaler interface {
+	MarshalLogArray(enc ArrayEncoder) error
+}
+
+// ArrayMarshalerFunc is an adapter to allow the use of ordinary functions as ArrayMarshaler.
+type ArrayMarshalerFunc func(enc ArrayEncoder) error
+
+// MarshalLogArray calls f(enc).
+func (f ArrayMarshalerFunc) MarshalLogArray(enc ArrayEncoder) error {
+	return f(enc)
+}
+
+// ArrayEncoder is the interface that has the methods for appending elements to an array.
+type ArrayEncoder interface {
+	AppendAny(value interface{})
+	AppendArray(marshaler ArrayMarshaler)
+	AppendBool(value bool)
+	AppendBytes(value []byte)
+	AppendDuration(value time.Duration)
+	AppendErr(err error)
+	AppendFloat32(value float32)
+	AppendFloat64(value float64)
+	AppendInt(value int)
+	AppendInt32(value int32)
+	AppendInt64(value int64)
+	AppendObject(marshaler ObjectMarshaler)
+	AppendString(value string)
+	AppendTime(value time.Time)
+	AppendUint(value uint)
+	AppendUint32(value uint32)
+	AppendUint64(value uint64)
+}
+
 // LogEntry is the interface that has the logging methods for a single log entry.
 type LogEntry interface {
 	// common is the interface that has the common logging methods for both ilog.Logger and ilog.LogEntry.
@@ -91,3 +206,10 @@ type LogEntry interface {
 	// error: for considering undispatched LogEntry as error so that they can be detected by Go static analysis.
 	error
 }
+
+// Syncer is the interface implemented by loggers and writers that can flush buffered log entries.
+// Since it is not a part of Logger, use a type assertion to check whether a logger implements it, or call Sync for the global logger.
+type Syncer interface {
+	// Sync flushes buffered log entries.
+	Sync() error
+}
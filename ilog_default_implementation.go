@@ -1,23 +1,29 @@
 package ilog
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math"
+	"os"
 	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 //nolint:gochecknoglobals
 var defaultLevels = map[Level]string{
+	TraceLevel: "TRACE",
 	DebugLevel: "DEBUG",
 	InfoLevel:  "INFO",
 	WarnLevel:  "WARN",
 	ErrorLevel: "ERROR",
+	PanicLevel: "PANIC",
+	FatalLevel: "FATAL",
 }
 
 func copyLevels(levels map[Level]string) map[Level]string {
@@ -32,21 +38,70 @@ func copyLevels(levels map[Level]string) map[Level]string {
 type implLoggerConfig struct {
 	levelKey        string
 	level           Level
+	levelVar        *LevelVar
+	levelRegistry   *LevelRegistry
+	name            string
+	nameKey         string
 	levels          map[Level]string
+	numericLevel    bool
 	timestampKey    string
 	timestampFormat string
 	timestampZone   *time.Location
 	callerKey       string
 	callerSkip      int
 	useLongCaller   bool
+	stacktraceKey   string
+	stacktraceLevel Level
+	structuredError bool
+	sampler         *Sampler
+	hooks           []Hook
 	messageKey      string
 	separator       string
+	encoder         Encoder
 	writer          io.Writer
 }
 
 type implLogger struct {
 	config implLoggerConfig
 	fields []byte
+	// hookFields is the fields of the logger recorded for the hooks. It is empty if the logger has no hooks.
+	hookFields []hookField
+	// callerPC is the program counter of the caller set by withCallerPC. If zero, the caller is found by callerSkip.
+	callerPC uintptr
+	// groups is the groups opened by Group and not closed yet. They are shared between copies of the logger, so they must not be modified.
+	groups []implGroup
+}
+
+// implGroup is a nested object or array that is being encoded.
+// Since the fields of nested objects and the elements of arrays are always encoded as JSON, they are embedded with Encoder.AppendJSON when they are closed.
+type implGroup struct {
+	key         string
+	array       bool
+	bytesBuffer *bytesBuffer
+	put         func()
+	hookFields  []hookField
+}
+
+// hookField is a field of the log entry recorded for HookEntry.Fields.
+type hookField struct {
+	key   string
+	value interface{}
+}
+
+// hookValue returns the fields or the elements of the group as map[string]interface{} or []interface{}.
+func (g implGroup) hookValue() interface{} {
+	if g.array {
+		elements := make([]interface{}, 0, len(g.hookFields))
+		for _, f := range g.hookFields {
+			elements = append(elements, f.value)
+		}
+		return elements
+	}
+	fields := make(map[string]interface{}, len(g.hookFields))
+	for _, f := range g.hookFields {
+		fields[f.key] = f.value
+	}
+	return fields
 }
 
 type syncWriter interface {
@@ -68,6 +123,21 @@ func (w *_syncWriter) Write(p []byte) (int, error) {
 func (w *_syncWriter) Lock()   { w.mu.Lock() }
 func (w *_syncWriter) Unlock() { w.mu.Unlock() }
 
+// Sync flushes the underlying writer if it implements Syncer.
+func (w *_syncWriter) Sync() error {
+	w.Lock()
+	defer w.Unlock()
+	return syncWriterOf(w.w)
+}
+
+// syncWriterOf flushes w if it implements Syncer, such as *os.File and the writers returned by NewSyncWriter and NewAsyncWriter.
+func syncWriterOf(w io.Writer) error {
+	if s, ok := w.(Syncer); ok {
+		return s.Sync() //nolint:wrapcheck
+	}
+	return nil
+}
+
 func NewSyncWriter(w io.Writer) io.Writer {
 	return &_syncWriter{w: w}
 }
@@ -78,15 +148,26 @@ func NewBuilder(level Level, w io.Writer) implLoggerConfig { //nolint:revive
 	return implLoggerConfig{
 		levelKey:        "severity",
 		level:           level,
-		levels:          copyLevels(defaultLevels),
+		levelVar:        nil,
+		levelRegistry:   nil,
+		name:            "",
+		nameKey:         "logger",
+		levels:          copyLevels(levelNames()),
+		numericLevel:    false,
 		timestampKey:    "timestamp",
 		timestampFormat: time.RFC3339Nano,
 		timestampZone:   time.Local, //nolint:gosmopolitan
 		callerKey:       "caller",
 		callerSkip:      defaultCallerSkip,
 		useLongCaller:   false,
+		stacktraceKey:   "",
+		stacktraceLevel: ErrorLevel,
+		structuredError: false,
+		sampler:         nil,
+		hooks:           nil,
 		messageKey:      "message",
 		separator:       "\n",
+		encoder:         NewJSONEncoder(),
 		writer:          w,
 	}
 }
@@ -99,12 +180,45 @@ func (c implLoggerConfig) SetLevelKey(key string) implLoggerConfig { //nolint:re
 	return c
 }
 
-// SetLevel sets the level of the logger.
+// SetLevelVar sets the LevelVar that the logger reads its level from, instead of the level passed to NewBuilder.
+// The LevelVar is shared by the copies of the logger, so changing it changes the level of all of them at runtime.
+// Default is nil.
+func (c implLoggerConfig) SetLevelVar(v *LevelVar) implLoggerConfig { //nolint:revive
+	c.levelVar = v
+	return c
+}
+
+// SetLevelRegistry sets the LevelRegistry that configures the levels of the loggers named by Named.
+// If a pattern of the registry matches the name of a logger, the logger uses the level of the pattern instead of its level.
+// Default is nil.
+func (c implLoggerConfig) SetLevelRegistry(r *LevelRegistry) implLoggerConfig { //nolint:revive
+	c.levelRegistry = r
+	return c
+}
+
+// SetNameKey sets the key of the logger name field set by Named.
+// If empty, the logger name field is not output.
+// Default is "logger".
+func (c implLoggerConfig) SetNameKey(key string) implLoggerConfig { //nolint:revive
+	c.nameKey = key
+	return c
+}
+
+// SetLevels sets the names of the levels of the logger.
+// A level that has no name is output as the name of the nearest lower level with the difference, such as "INFO+4".
+// Default is the names of the predefined levels and the levels registered by RegisterLevel when NewBuilder is called.
 func (c implLoggerConfig) SetLevels(levels map[Level]string) implLoggerConfig { //nolint:revive
 	c.levels = levels
 	return c
 }
 
+// UseNumericLevel sets whether to output the level field as a number such as 8 instead of a name such as "WARN".
+// Default is false.
+func (c implLoggerConfig) UseNumericLevel(useNumericLevel bool) implLoggerConfig { //nolint:revive
+	c.numericLevel = useNumericLevel
+	return c
+}
+
 // SetTimestampKey sets the key of the timestamp field of the logger.
 // If empty, the timestamp field is not output.
 // Default is "timestamp".
@@ -143,6 +257,46 @@ func (c implLoggerConfig) UseLongCaller(useLongCaller bool) implLoggerConfig { /
 	return c
 }
 
+// SetStacktraceKey sets the key of the stack trace field of the logger.
+// If not empty, the stack trace of the goroutine is added to the log entries whose level is higher than or equal to the level set by SetStacktraceLevel.
+// Default is empty, so the stack trace field is not output.
+func (c implLoggerConfig) SetStacktraceKey(key string) implLoggerConfig { //nolint:revive
+	c.stacktraceKey = key
+	return c
+}
+
+// SetStacktraceLevel sets the minimum level of the log entries that have the stack trace field.
+// Default is ErrorLevel.
+func (c implLoggerConfig) SetStacktraceLevel(level Level) implLoggerConfig { //nolint:revive
+	c.stacktraceLevel = level
+	return c
+}
+
+// UseStructuredError sets whether to output errors as structured objects.
+// If true, an error is output as an object that has "message", "type", "stacktrace" if the error has `StackTrace()` like github.com/pkg/errors,
+// and "causes" which is the errors returned by `Unwrap() error` or `Unwrap() []error` such as errors.Join.
+// Default is false, so an error is output as a string.
+func (c implLoggerConfig) UseStructuredError(useStructuredError bool) implLoggerConfig { //nolint:revive
+	c.structuredError = useStructuredError
+	return c
+}
+
+// SetSampler sets the Sampler that limits the number of log entries that have the same level and message.
+// The sampler is evaluated before the log entry is encoded, and the dropped log entries are not written.
+// Default is nil, so all log entries are written.
+func (c implLoggerConfig) SetSampler(sampler *Sampler) implLoggerConfig { //nolint:revive
+	c.sampler = sampler
+	return c
+}
+
+// AddHook adds the hooks that are called for every log entry written by the logger.
+// The hooks are called synchronously after the log entry is written. Use NewAsyncHook to call a hook asynchronously.
+// HookEntry.Fields is set regardless of the Encoder, since the fields are recorded as they are added to the logger.
+func (c implLoggerConfig) AddHook(hooks ...Hook) implLoggerConfig { //nolint:revive
+	c.hooks = append(append(make([]Hook, 0, len(c.hooks)+len(hooks)), c.hooks...), hooks...)
+	return c
+}
+
 // SetMessageKey sets the key of the message field of the logger.
 // If empty, the message field is not output.
 // Default is "message".
@@ -158,6 +312,31 @@ func (c implLoggerConfig) SetSeparator(separator string) implLoggerConfig { //no
 	return c
 }
 
+// SetEncoder sets the Encoder that encodes log entries of the logger.
+// Default is the JSON encoder returned by NewJSONEncoder.
+func (c implLoggerConfig) SetEncoder(enc Encoder) implLoggerConfig { //nolint:revive
+	c.encoder = enc
+	return c
+}
+
+// UseConsoleFormat sets the logger to output human-readable lines instead of JSON objects.
+// Each line is rendered as `TIMESTAMP LEVEL caller message key=value ...`, and the level names are aligned to the longest name set by SetLevels.
+// If useColor is true, the level names are colored with ANSI escape sequences.
+// The keys of the level, timestamp, caller and message fields are not output, but each field is still omitted if its key is empty.
+// Nested values such as Object, Array and Group are written as JSON, e.g. `user={"name":"gopher"}`.
+func (c implLoggerConfig) UseConsoleFormat(useColor bool) implLoggerConfig { //nolint:revive
+	c.encoder = NewConsoleEncoder(useColor)
+	return c
+}
+
+// UseLogfmtFormat sets the logger to output logfmt lines instead of JSON objects.
+// The level, timestamp, caller, message and every field are rendered as `key=value` pairs, and the values are quoted only if needed.
+// Nested values such as Object, Array and Group are written as quoted JSON, e.g. `user="{\"name\":\"gopher\"}"`.
+func (c implLoggerConfig) UseLogfmtFormat() implLoggerConfig { //nolint:revive
+	c.encoder = NewLogfmtEncoder()
+	return c
+}
+
 // UseSyncWriter sets whether to use sync writer of the logger.
 func (c implLoggerConfig) UseSyncWriter() implLoggerConfig { //nolint:revive
 	switch v := c.writer.(type) {
@@ -172,6 +351,10 @@ func (c implLoggerConfig) UseSyncWriter() implLoggerConfig { //nolint:revive
 
 // Build returns a new ilog.Logger with the specified configuration.
 func (c implLoggerConfig) Build() Logger { //nolint:ireturn
+	if enc, ok := c.encoder.(*consoleEncoder); ok {
+		c.encoder = enc.withLevels(c.levels)
+	}
+
 	const fieldsCap = 1024
 	return &implLogger{
 		config: c,
@@ -180,11 +363,17 @@ func (c implLoggerConfig) Build() Logger { //nolint:ireturn
 }
 
 func (l *implLogger) Level() Level {
+	if l.config.levelVar != nil {
+		return l.config.levelVar.Level()
+	}
 	return l.config.level
 }
 
+// SetLevel returns a copy of the logger with the level.
+// If the logger has a LevelVar set by SetLevelVar, the copy no longer reads it, so the level of the other loggers sharing it is not changed. To change all of them, use LevelVar.Set.
 func (l *implLogger) SetLevel(level Level) Logger { //nolint:ireturn
 	copied := l.copy()
+	copied.config.levelVar = nil
 	copied.config.level = level
 	return copied
 }
@@ -199,10 +388,35 @@ func (l *implLogger) Copy() Logger { //nolint:ireturn
 	return l.copy()
 }
 
+// withCallerPC returns a copy of the logger that reports the caller at pc instead of the caller of the logging method.
+func (l *implLogger) withCallerPC(pc uintptr) Logger { //nolint:ireturn
+	copied := l.copy()
+	copied.callerPC = pc
+	return copied
+}
+
+// Sync flushes the writer of the logger if it implements Syncer.
+func (l *implLogger) Sync() error {
+	return syncWriterOf(l.config.writer)
+}
+
+func (l *implLogger) Named(name string) Logger { //nolint:ireturn
+	copied := l.copy()
+	copied.config.name = joinName(l.config.name, name)
+	if r := copied.config.levelRegistry; r != nil {
+		if v := r.LevelVar(copied.config.name); v != nil {
+			copied.config.levelVar = v
+		}
+	}
+	return copied
+}
+
 func (l *implLogger) copy() *implLogger {
 	copied := *l
 	copied.fields = make([]byte, len(l.fields))
 	copy(copied.fields, l.fields)
+	// NOTE: cap the slice so that appending to the copy never modifies the original.
+	copied.hookFields = l.hookFields[:len(l.hookFields):len(l.hookFields)]
 	return &copied
 }
 
@@ -270,6 +484,62 @@ func (l *implLogger) Uint64(key string, value uint64) LogEntry { //nolint:iretur
 	return l.new().Uint64(key, value)
 }
 
+func (l *implLogger) Object(key string, marshaler ObjectMarshaler) LogEntry { //nolint:ireturn
+	return l.new().Object(key, marshaler)
+}
+
+func (l *implLogger) Group(name string) LogEntry { //nolint:ireturn
+	return l.new().Group(name)
+}
+
+func (l *implLogger) Array(key string, marshaler ArrayMarshaler) LogEntry { //nolint:ireturn
+	return l.new().Array(key, marshaler)
+}
+
+func (l *implLogger) Bools(key string, values []bool) LogEntry { //nolint:ireturn
+	return l.new().Bools(key, values)
+}
+
+func (l *implLogger) Durations(key string, values []time.Duration) LogEntry { //nolint:ireturn
+	return l.new().Durations(key, values)
+}
+
+func (l *implLogger) Errs(key string, errs []error) LogEntry { //nolint:ireturn
+	return l.new().Errs(key, errs)
+}
+
+func (l *implLogger) Float64s(key string, values []float64) LogEntry { //nolint:ireturn
+	return l.new().Float64s(key, values)
+}
+
+func (l *implLogger) Ints(key string, values []int) LogEntry { //nolint:ireturn
+	return l.new().Ints(key, values)
+}
+
+func (l *implLogger) Int64s(key string, values []int64) LogEntry { //nolint:ireturn
+	return l.new().Int64s(key, values)
+}
+
+func (l *implLogger) Strings(key string, values []string) LogEntry { //nolint:ireturn
+	return l.new().Strings(key, values)
+}
+
+func (l *implLogger) Times(key string, values []time.Time) LogEntry { //nolint:ireturn
+	return l.new().Times(key, values)
+}
+
+func (l *implLogger) Stack(key string) LogEntry { //nolint:ireturn
+	return l.new().stack(key)
+}
+
+func (l *implLogger) Ctx(ctx context.Context) LogEntry { //nolint:ireturn
+	return ExtractContext(ctx, l.new())
+}
+
+func (l *implLogger) Tracef(format string, args ...interface{}) {
+	_ = l.new().logf(TraceLevel, format, args...)
+}
+
 func (l *implLogger) Debugf(format string, args ...interface{}) {
 	_ = l.new().logf(DebugLevel, format, args...)
 }
@@ -286,12 +556,24 @@ func (l *implLogger) Errorf(format string, args ...interface{}) {
 	_ = l.new().logf(ErrorLevel, format, args...)
 }
 
+func (l *implLogger) Panicf(format string, args ...interface{}) {
+	_ = l.new().logf(PanicLevel, format, args...)
+	_ = l.Sync()
+	panic(sprintf(format, args...))
+}
+
+func (l *implLogger) Fatalf(format string, args ...interface{}) {
+	_ = l.new().logf(FatalLevel, format, args...)
+	_ = l.Sync()
+	Exit(1)
+}
+
 func (l *implLogger) Logf(level Level, format string, args ...interface{}) {
 	_ = l.new().logf(level, format, args...)
 }
 
 func (l *implLogger) Write(p []byte) (int, error) {
-	if err := l.new().logf(l.config.level, string(p)); err != nil {
+	if err := l.new().logf(l.Level(), string(p)); err != nil {
 		return 0, fmt.Errorf("w.logf: %w", err)
 	}
 	return len(p), nil
@@ -299,11 +581,18 @@ func (l *implLogger) Write(p []byte) (int, error) {
 
 func (l *implLogger) new() *implLogEntry {
 	buffer, put := getBytesBuffer()
-	return &implLogEntry{
+	e := &implLogEntry{
 		logger:      l,
 		bytesBuffer: buffer,
 		put:         put,
 	}
+	for _, g := range l.groups {
+		e.open(g.key, g.array)
+		opened := &e.groups[len(e.groups)-1]
+		opened.bytesBuffer.bytes = append(opened.bytesBuffer.bytes[:0], g.bytesBuffer.bytes...)
+		opened.hookFields = append([]hookField(nil), g.hookFields...)
+	}
+	return e
 }
 
 //nolint:errname
@@ -311,6 +600,89 @@ type implLogEntry struct {
 	logger      *implLogger
 	bytesBuffer *bytesBuffer
 	put         func()
+	groups      []implGroup
+	hookFields  []hookField
+}
+
+// hooked reports whether the logger has hooks, i.e. whether the fields must be recorded for them.
+func (e *implLogEntry) hooked() bool {
+	return len(e.logger.config.hooks) > 0
+}
+
+// addHookField records the field for the hooks into the innermost group or the log entry.
+func (e *implLogEntry) addHookField(key string, value interface{}) {
+	if !e.hooked() {
+		return
+	}
+	if n := len(e.groups); n > 0 {
+		e.groups[n-1].hookFields = append(e.groups[n-1].hookFields, hookField{key: key, value: value})
+		return
+	}
+	e.hookFields = append(e.hookFields, hookField{key: key, value: value})
+}
+
+// target returns the Encoder and the buffer that the next field is appended to.
+// If a group is open, the field is encoded as JSON into the innermost group regardless of the configured Encoder,
+// and the closed group is appended to its parent by AppendJSON of the Encoder, since Encoder has no methods for nested values.
+func (e *implLogEntry) target() (Encoder, *bytesBuffer) { //nolint:ireturn
+	if n := len(e.groups); n > 0 {
+		if e.groups[n-1].array {
+			return arrayElementEncoder{}, e.groups[n-1].bytesBuffer
+		}
+		return jsonEncoder{}, e.groups[n-1].bytesBuffer
+	}
+	return e.logger.config.encoder, e.bytesBuffer
+}
+
+func (e *implLogEntry) open(key string, array bool) {
+	buffer, put := getBytesBuffer()
+	if array {
+		buffer.bytes = append(buffer.bytes, '[')
+	} else {
+		buffer.bytes = append(buffer.bytes, '{')
+	}
+	e.groups = append(e.groups, implGroup{key: key, array: array, bytesBuffer: buffer, put: put})
+}
+
+func (e *implLogEntry) openGroup(key string) {
+	e.open(key, false)
+}
+
+func (e *implLogEntry) openArray(key string) {
+	e.open(key, true)
+}
+
+// closeGroup closes the innermost group and appends it to the parent as a field.
+func (e *implLogEntry) closeGroup() {
+	g := e.groups[len(e.groups)-1]
+	e.groups = e.groups[:len(e.groups)-1]
+	defer g.put()
+
+	if g.array {
+		g.bytesBuffer.bytes = endJSON(g.bytesBuffer.bytes, ']')
+	} else {
+		g.bytesBuffer.bytes = endJSON(g.bytesBuffer.bytes, '}')
+	}
+	var value interface{}
+	if e.hooked() {
+		value = g.hookValue()
+	}
+	_ = e.json(g.key, g.bytesBuffer.bytes, value)
+}
+
+// dropGroups discards the groups until the number of the open groups becomes depth.
+func (e *implLogEntry) dropGroups(depth int) {
+	for len(e.groups) > depth {
+		e.groups[len(e.groups)-1].put()
+		e.groups = e.groups[:len(e.groups)-1]
+	}
+}
+
+// closeGroups closes the groups until the number of the open groups becomes depth.
+func (e *implLogEntry) closeGroups(depth int) {
+	for len(e.groups) > depth {
+		e.closeGroup()
+	}
 }
 
 func (*implLogEntry) Error() string {
@@ -318,9 +690,21 @@ func (*implLogEntry) Error() string {
 }
 
 func (e *implLogEntry) null(key string) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, null...)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendNull(b.bytes)
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, nil)
+	return e
+}
+
+// json adds the field that has already been encoded as JSON. hookValue is the value of the field recorded for the hooks.
+func (e *implLogEntry) json(key string, value []byte, hookValue interface{}) LogEntry { //nolint:ireturn
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendJSON(b.bytes, value)
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, hookValue)
 	return e
 }
 
@@ -371,6 +755,26 @@ func (e *implLogEntry) Any(key string, value interface{}) (le LogEntry) { //noli
 		return e.Uint32(key, v)
 	case uint64:
 		return e.Uint64(key, v)
+	case []bool:
+		return e.Bools(key, v)
+	case []time.Duration:
+		return e.Durations(key, v)
+	case []error:
+		return e.Errs(key, v)
+	case []float64:
+		return e.Float64s(key, v)
+	case []int:
+		return e.Ints(key, v)
+	case []int64:
+		return e.Int64s(key, v)
+	case []string:
+		return e.Strings(key, v)
+	case []time.Time:
+		return e.Times(key, v)
+	case ObjectMarshaler:
+		return e.Object(key, v)
+	case ArrayMarshaler:
+		return e.Array(key, v)
 	case json.Marshaler:
 		defer func() {
 			if p := recover(); p != nil {
@@ -382,10 +786,7 @@ func (e *implLogEntry) Any(key string, value interface{}) (le LogEntry) { //noli
 		if err != nil {
 			return e.ErrWithKey(key, fmt.Errorf("json.Marshaler: v.MarshalJSON: %w", err))
 		}
-		e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-		e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, b...)
-		e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, ',')
-		return e
+		return e.json(key, b, v)
 	case fmt.Formatter:
 		return e.String(key, fmt.Sprintf("%+v", v))
 	case fmt.Stringer:
@@ -401,33 +802,34 @@ func (e *implLogEntry) Any(key string, value interface{}) (le LogEntry) { //noli
 		if err != nil {
 			return e.String(key, fmt.Sprintf("%v", v))
 		}
-		e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-		e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, b...)
-		e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, ',')
-		return e
+		return e.json(key, b, v)
 	}
 }
 
 func (e *implLogEntry) Bool(key string, value bool) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	e.bytesBuffer.bytes = strconv.AppendBool(e.bytesBuffer.bytes, value)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendBool(b.bytes, value)
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, value)
 	return e
 }
 
 func (e *implLogEntry) Bytes(key string, value []byte) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, '"')
-	e.bytesBuffer.bytes = appendJSONEscapedString(e.bytesBuffer.bytes, string(value))
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, '"', ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendBytes(b.bytes, value)
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, string(value))
 	return e
 }
 
 func (e *implLogEntry) Duration(key string, value time.Duration) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, '"')
-	e.bytesBuffer.bytes = appendJSONEscapedString(e.bytesBuffer.bytes, value.String())
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, '"', ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendDuration(b.bytes, value)
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, value)
 	return e
 }
 
@@ -442,6 +844,10 @@ func (e *implLogEntry) ErrWithKey(key string, err error) (le LogEntry) { //nolin
 		}
 	}()
 
+	if err != nil && e.logger.config.structuredError {
+		return e.Object(key, errorObject{err: err})
+	}
+
 	// NOTE: Even if err is your unique error type and nil, it is not judged as nil because it has type information. Calling err.Error() causes panic.
 	var v string
 	formatter, ok := err.(fmt.Formatter) //nolint:errorlint
@@ -450,100 +856,284 @@ func (e *implLogEntry) ErrWithKey(key string, err error) (le LogEntry) { //nolin
 	} else {
 		v = err.Error()
 	}
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, '"')
-	e.bytesBuffer.bytes = appendJSONEscapedString(e.bytesBuffer.bytes, v)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, '"')
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendError(b.bytes, v)
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, err)
 	return e
 }
 
 func (e *implLogEntry) Float32(key string, value float32) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	const bitSize = 32
-	e.bytesBuffer.bytes = appendFloatFieldValue(e.bytesBuffer.bytes, float64(value), bitSize)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendFloat32(b.bytes, value)
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, value)
 	return e
 }
 
 func (e *implLogEntry) Float64(key string, value float64) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	const bitSize = 64
-	e.bytesBuffer.bytes = appendFloatFieldValue(e.bytesBuffer.bytes, value, bitSize)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendFloat64(b.bytes, value)
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, value)
 	return e
 }
 
 func (e *implLogEntry) Int(key string, value int) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	const base = 10
-	e.bytesBuffer.bytes = strconv.AppendInt(e.bytesBuffer.bytes, int64(value), base)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendInt64(b.bytes, int64(value))
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, value)
 	return e
 }
 
 func (e *implLogEntry) Int32(key string, value int32) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	const base = 10
-	e.bytesBuffer.bytes = strconv.AppendInt(e.bytesBuffer.bytes, int64(value), base)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendInt64(b.bytes, int64(value))
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, value)
 	return e
 }
 
 func (e *implLogEntry) Int64(key string, value int64) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	const base = 10
-	e.bytesBuffer.bytes = strconv.AppendInt(e.bytesBuffer.bytes, value, base)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendInt64(b.bytes, value)
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, value)
 	return e
 }
 
 func (e *implLogEntry) String(key string, value string) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, '"')
-	e.bytesBuffer.bytes = appendJSONEscapedString(e.bytesBuffer.bytes, value)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, '"', ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendString(b.bytes, value)
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, value)
 	return e
 }
 
 func (e *implLogEntry) Time(key string, value time.Time) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, '"')
-	e.bytesBuffer.bytes = appendJSONEscapedString(e.bytesBuffer.bytes, value.Format(e.logger.config.timestampFormat))
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, '"', ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendTime(b.bytes, value, e.logger.config.timestampFormat)
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, value)
 	return e
 }
 
 func (e *implLogEntry) Uint(key string, value uint) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	const base = 10
-	e.bytesBuffer.bytes = strconv.AppendUint(e.bytesBuffer.bytes, uint64(value), base)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendUint64(b.bytes, uint64(value))
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, value)
 	return e
 }
 
 func (e *implLogEntry) Uint32(key string, value uint32) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	const base = 10
-	e.bytesBuffer.bytes = strconv.AppendUint(e.bytesBuffer.bytes, uint64(value), base)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendUint64(b.bytes, uint64(value))
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, value)
 	return e
 }
 
 func (e *implLogEntry) Uint64(key string, value uint64) LogEntry { //nolint:ireturn
-	e.bytesBuffer.bytes = appendKey(e.bytesBuffer.bytes, key)
-	const base = 10
-	e.bytesBuffer.bytes = strconv.AppendUint(e.bytesBuffer.bytes, value, base)
-	e.bytesBuffer.bytes = append(e.bytesBuffer.bytes, ',')
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendUint64(b.bytes, value)
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, value)
+	return e
+}
+
+// Object adds a nested object field.
+// If the marshaler returns an error, the error is added as a sibling field with the key suffixed by "Error".
+func (e *implLogEntry) Object(key string, marshaler ObjectMarshaler) (le LogEntry) { //nolint:ireturn
+	depth := len(e.groups)
+	defer func() {
+		if p := recover(); p != nil {
+			// NOTE: Drop the fields that were added before panic.
+			e.dropGroups(depth)
+			le = e.null(key)
+		}
+	}()
+
+	// NOTE: Even if marshaler is nil, it is not judged as nil because it may have type information. Calling marshaler.MarshalLogObject() causes panic.
+	e.openGroup(key)
+	err := marshaler.MarshalLogObject(implObjectEncoder{entry: e})
+	e.closeGroups(depth)
+	if err != nil {
+		return e.ErrWithKey(key+"Error", err)
+	}
+	return e
+}
+
+func (e *implLogEntry) Group(name string) LogEntry { //nolint:ireturn
+	e.openGroup(name)
+	return e
+}
+
+// Array adds an array field.
+// If the marshaler returns an error, the error is added as a sibling field with the key suffixed by "Error".
+func (e *implLogEntry) Array(key string, marshaler ArrayMarshaler) (le LogEntry) { //nolint:ireturn
+	depth := len(e.groups)
+	defer func() {
+		if p := recover(); p != nil {
+			// NOTE: Drop the elements that were added before panic.
+			e.dropGroups(depth)
+			le = e.null(key)
+		}
+	}()
+
+	// NOTE: Even if marshaler is nil, it is not judged as nil because it may have type information. Calling marshaler.MarshalLogArray() causes panic.
+	e.openArray(key)
+	err := marshaler.MarshalLogArray(implArrayEncoder{entry: e})
+	e.closeGroups(depth)
+	if err != nil {
+		return e.ErrWithKey(key+"Error", err)
+	}
+	return e
+}
+
+func (e *implLogEntry) Bools(key string, values []bool) LogEntry { //nolint:ireturn
+	if values == nil {
+		return e.null(key)
+	}
+	e.openArray(key)
+	for _, v := range values {
+		e.Bool("", v)
+	}
+	e.closeGroup()
+	return e
+}
+
+func (e *implLogEntry) Durations(key string, values []time.Duration) LogEntry { //nolint:ireturn
+	if values == nil {
+		return e.null(key)
+	}
+	e.openArray(key)
+	for _, v := range values {
+		e.Duration("", v)
+	}
+	e.closeGroup()
+	return e
+}
+
+func (e *implLogEntry) Errs(key string, errs []error) LogEntry { //nolint:ireturn
+	if errs == nil {
+		return e.null(key)
+	}
+	e.openArray(key)
+	for _, err := range errs {
+		e.ErrWithKey("", err)
+	}
+	e.closeGroup()
+	return e
+}
+
+func (e *implLogEntry) Float64s(key string, values []float64) LogEntry { //nolint:ireturn
+	if values == nil {
+		return e.null(key)
+	}
+	e.openArray(key)
+	for _, v := range values {
+		e.Float64("", v)
+	}
+	e.closeGroup()
+	return e
+}
+
+func (e *implLogEntry) Ints(key string, values []int) LogEntry { //nolint:ireturn
+	if values == nil {
+		return e.null(key)
+	}
+	e.openArray(key)
+	for _, v := range values {
+		e.Int("", v)
+	}
+	e.closeGroup()
+	return e
+}
+
+func (e *implLogEntry) Int64s(key string, values []int64) LogEntry { //nolint:ireturn
+	if values == nil {
+		return e.null(key)
+	}
+	e.openArray(key)
+	for _, v := range values {
+		e.Int64("", v)
+	}
+	e.closeGroup()
+	return e
+}
+
+func (e *implLogEntry) Strings(key string, values []string) LogEntry { //nolint:ireturn
+	if values == nil {
+		return e.null(key)
+	}
+	e.openArray(key)
+	for _, v := range values {
+		e.String("", v)
+	}
+	e.closeGroup()
+	return e
+}
+
+func (e *implLogEntry) Times(key string, values []time.Time) LogEntry { //nolint:ireturn
+	if values == nil {
+		return e.null(key)
+	}
+	e.openArray(key)
+	for _, v := range values {
+		e.Time("", v)
+	}
+	e.closeGroup()
+	return e
+}
+
+func (e *implLogEntry) Stack(key string) LogEntry { //nolint:ireturn
+	return e.stack(key)
+}
+
+// stack adds the stack trace field. It must be called directly from Stack of implLogger or implLogEntry.
+func (e *implLogEntry) stack(key string) LogEntry { //nolint:ireturn
+	// NOTE: the number of the frames of runtime.Callers, stacktrace, stack and Stack is the same as that of caller.
+	v := stacktrace(e.logger.config.callerSkip)
+	enc, b := e.target()
+	b.bytes = enc.AppendKey(b.bytes, key)
+	b.bytes = enc.AppendError(b.bytes, v)
+	b.bytes = enc.AppendFieldDelimiter(b.bytes)
+	e.addHookField(key, v)
 	return e
 }
 
 func (e *implLogEntry) Logger() Logger { //nolint:ireturn
 	copied := e.logger.copy()
 	copied.fields = append(copied.fields, e.bytesBuffer.bytes...)
+	copied.hookFields = append(copied.hookFields, e.hookFields...)
+	copied.groups = make([]implGroup, len(e.groups))
+	for i, g := range e.groups {
+		copied.groups[i] = implGroup{key: g.key, array: g.array, bytesBuffer: &bytesBuffer{bytes: append([]byte(nil), g.bytesBuffer.bytes...)}, hookFields: append([]hookField(nil), g.hookFields...)}
+	}
 	return copied
 }
 
+func (e *implLogEntry) Ctx(ctx context.Context) LogEntry { //nolint:ireturn
+	return ExtractContext(ctx, e)
+}
+
+func (e *implLogEntry) Tracef(format string, args ...interface{}) {
+	_ = e.logf(TraceLevel, format, args...)
+}
+
 func (e *implLogEntry) Debugf(format string, args ...interface{}) {
 	_ = e.logf(DebugLevel, format, args...)
 }
@@ -560,12 +1150,24 @@ func (e *implLogEntry) Errorf(format string, args ...interface{}) {
 	_ = e.logf(ErrorLevel, format, args...)
 }
 
+func (e *implLogEntry) Panicf(format string, args ...interface{}) {
+	_ = e.logf(PanicLevel, format, args...)
+	_ = e.logger.Sync()
+	panic(sprintf(format, args...))
+}
+
+func (e *implLogEntry) Fatalf(format string, args ...interface{}) {
+	_ = e.logf(FatalLevel, format, args...)
+	_ = e.logger.Sync()
+	Exit(1)
+}
+
 func (e *implLogEntry) Logf(level Level, format string, args ...interface{}) {
 	_ = e.logf(level, format, args...)
 }
 
 func (e *implLogEntry) Write(p []byte) (int, error) {
-	if err := e.logf(e.logger.config.level, string(p)); err != nil {
+	if err := e.logf(e.logger.Level(), string(p)); err != nil {
 		return 0, fmt.Errorf("w.logf: %w", err)
 	}
 	return len(p), nil
@@ -574,41 +1176,62 @@ func (e *implLogEntry) Write(p []byte) (int, error) {
 //nolint:cyclop
 func (e *implLogEntry) logf(level Level, format string, args ...interface{}) error {
 	defer e.put()
-	if level < e.logger.config.level {
+	if level < e.logger.Level() {
 		return nil
 	}
 
+	if s := e.logger.config.sampler; s != nil && !s.sample(level, format, time.Now()) {
+		return nil
+	}
+
+	e.closeGroups(0)
+
 	b, put := getBytesBuffer()
 	defer put()
 
-	b.bytes = append(b.bytes, '{')
-
-	if len(e.logger.config.levelKey) > 0 {
-		b.bytes = appendKey(b.bytes, e.logger.config.levelKey)
-		b.bytes = appendLevelField(b.bytes, e.logger.config.levels, level)
-		b.bytes = append(b.bytes, ',')
-	}
-	if len(e.logger.config.timestampKey) > 0 {
-		b.bytes = appendKey(b.bytes, e.logger.config.timestampKey)
-		b.bytes = append(b.bytes, '"')
-		b.bytes = appendJSONEscapedString(b.bytes, time.Now().In(e.logger.config.timestampZone).Format(e.logger.config.timestampFormat))
-		b.bytes = append(b.bytes, '"', ',')
-	}
-	if len(e.logger.config.callerKey) > 0 {
-		b.bytes = appendKey(b.bytes, e.logger.config.callerKey)
-		b.bytes = append(b.bytes, '"')
-		b.bytes = appendCaller(b.bytes, e.logger.config.callerSkip, e.logger.config.useLongCaller)
-		b.bytes = append(b.bytes, '"', ',')
-	}
-	if len(e.logger.config.messageKey) > 0 {
-		b.bytes = appendKey(b.bytes, e.logger.config.messageKey)
-		b.bytes = append(b.bytes, '"')
-		if len(args) > 0 {
-			b.bytes = appendJSONEscapedString(b.bytes, fmt.Sprintf(format, args...))
+	c := &e.logger.config
+	header := EntryHeader{
+		LevelKey:        c.levelKey,
+		Level:           level,
+		TimestampKey:    c.timestampKey,
+		TimestampFormat: c.timestampFormat,
+		CallerKey:       c.callerKey,
+		MessageKey:      c.messageKey,
+	}
+	hooked := len(c.hooks) > 0
+	if len(c.levelKey) > 0 {
+		if c.numericLevel {
+			header.LevelName, header.NumericLevel = strconv.Itoa(int(level)), true
+		} else {
+			header.LevelName = levelName(c.levels, level)
+		}
+	}
+	if len(c.timestampKey) > 0 || hooked {
+		header.Timestamp = time.Now().In(c.timestampZone)
+	}
+	if len(c.callerKey) > 0 || hooked {
+		if pc := e.logger.callerPC; pc != 0 {
+			frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+			header.CallerFile, header.CallerLine = callerFromFrame(frame, c.useLongCaller)
 		} else {
-			b.bytes = appendJSONEscapedString(b.bytes, format)
+			header.CallerFile, header.CallerLine = caller(c.callerSkip, c.useLongCaller)
 		}
-		b.bytes = append(b.bytes, '"', ',')
+	}
+	var stack string
+	if len(c.stacktraceKey) > 0 && level >= c.stacktraceLevel {
+		stack = stacktrace(c.callerSkip)
+	}
+	if len(c.messageKey) > 0 || hooked {
+		header.Message = sprintf(format, args...)
+	}
+
+	enc := c.encoder
+	b.bytes = enc.BeginEntry(b.bytes, header)
+
+	if len(c.name) > 0 && len(c.nameKey) > 0 {
+		b.bytes = enc.AppendKey(b.bytes, c.nameKey)
+		b.bytes = enc.AppendString(b.bytes, c.name)
+		b.bytes = enc.AppendFieldDelimiter(b.bytes)
 	}
 
 	if len(e.logger.fields) > 0 {
@@ -619,21 +1242,250 @@ func (e *implLogEntry) logf(level Level, format string, args ...interface{}) err
 		b.bytes = append(b.bytes, e.bytesBuffer.bytes...)
 	}
 
-	if b.bytes[len(b.bytes)-1] == ',' {
-		b.bytes[len(b.bytes)-1] = '}'
-	} else {
-		b.bytes = append(b.bytes, '}')
+	if len(stack) > 0 {
+		b.bytes = enc.AppendKey(b.bytes, c.stacktraceKey)
+		b.bytes = enc.AppendError(b.bytes, stack)
+		b.bytes = enc.AppendFieldDelimiter(b.bytes)
 	}
 
+	b.bytes = enc.EndEntry(b.bytes)
+
 	if _, err := e.logger.config.writer.Write(append(b.bytes, e.logger.config.separator...)); err != nil {
 		err = fmt.Errorf("w.logger.writer.Write: p=%s: %w", b.bytes, err)
-		defer Global().Errorf(err.Error())
+		reportWriteError(err)
 		return err
 	}
 
+	if hooked {
+		e.runHooks(header, stack)
+	}
+
 	return nil
 }
 
+//nolint:gochecknoglobals
+var _reportingWriteError int32
+
+// reportWriteError logs the error of writing a log entry through the global logger.
+// If the global logger fails to write while reporting, e.g. since it uses the same closed AsyncWriter, the error is written to os.Stderr instead, so that the report does not recurse forever.
+func reportWriteError(err error) {
+	if !atomic.CompareAndSwapInt32(&_reportingWriteError, 0, 1) {
+		_, _ = fmt.Fprintf(os.Stderr, "ilog: %v\n", err)
+		return
+	}
+	defer atomic.StoreInt32(&_reportingWriteError, 0)
+	Global().Errorf(err.Error())
+}
+
+// runHooks calls the hooks with the header and the fields of the log entry.
+// The fields are built from the fields recorded by addHookField, so they do not depend on the Encoder.
+func (e *implLogEntry) runHooks(header EntryHeader, stack string) {
+	c := &e.logger.config
+	entry := HookEntry{
+		Level:      header.Level,
+		Message:    header.Message,
+		Timestamp:  header.Timestamp,
+		CallerFile: header.CallerFile,
+		CallerLine: header.CallerLine,
+		Fields:     make(map[string]interface{}, len(e.logger.hookFields)+len(e.hookFields)+2), //nolint:gomnd
+	}
+	if len(c.name) > 0 && len(c.nameKey) > 0 {
+		entry.Fields[c.nameKey] = c.name
+	}
+	for _, f := range e.logger.hookFields {
+		entry.Fields[f.key] = f.value
+	}
+	for _, f := range e.hookFields {
+		entry.Fields[f.key] = f.value
+	}
+	if len(stack) > 0 {
+		entry.Fields[c.stacktraceKey] = stack
+	}
+
+	for _, hook := range c.hooks {
+		hook.Run(entry)
+	}
+}
+
+// implObjectEncoder is the ObjectEncoder that adds the fields of a nested object to the innermost group of the log entry.
+type implObjectEncoder struct {
+	entry *implLogEntry
+}
+
+func (enc implObjectEncoder) AddAny(key string, value interface{}) {
+	enc.entry.Any(key, value)
+}
+
+func (enc implObjectEncoder) AddBool(key string, value bool) {
+	enc.entry.Bool(key, value)
+}
+
+func (enc implObjectEncoder) AddBytes(key string, value []byte) {
+	enc.entry.Bytes(key, value)
+}
+
+func (enc implObjectEncoder) AddDuration(key string, value time.Duration) {
+	enc.entry.Duration(key, value)
+}
+
+func (enc implObjectEncoder) AddErr(key string, err error) {
+	enc.entry.ErrWithKey(key, err)
+}
+
+func (enc implObjectEncoder) AddFloat32(key string, value float32) {
+	enc.entry.Float32(key, value)
+}
+
+func (enc implObjectEncoder) AddFloat64(key string, value float64) {
+	enc.entry.Float64(key, value)
+}
+
+func (enc implObjectEncoder) AddInt(key string, value int) {
+	enc.entry.Int(key, value)
+}
+
+func (enc implObjectEncoder) AddInt32(key string, value int32) {
+	enc.entry.Int32(key, value)
+}
+
+func (enc implObjectEncoder) AddInt64(key string, value int64) {
+	enc.entry.Int64(key, value)
+}
+
+func (enc implObjectEncoder) AddObject(key string, marshaler ObjectMarshaler) {
+	enc.entry.Object(key, marshaler)
+}
+
+func (enc implObjectEncoder) AddString(key, value string) {
+	enc.entry.String(key, value)
+}
+
+func (enc implObjectEncoder) AddTime(key string, value time.Time) {
+	enc.entry.Time(key, value)
+}
+
+func (enc implObjectEncoder) AddUint(key string, value uint) {
+	enc.entry.Uint(key, value)
+}
+
+func (enc implObjectEncoder) AddUint32(key string, value uint32) {
+	enc.entry.Uint32(key, value)
+}
+
+func (enc implObjectEncoder) AddUint64(key string, value uint64) {
+	enc.entry.Uint64(key, value)
+}
+
+func (enc implObjectEncoder) AddArray(key string, marshaler ArrayMarshaler) {
+	enc.entry.Array(key, marshaler)
+}
+
+func (enc implObjectEncoder) AddBools(key string, values []bool) {
+	enc.entry.Bools(key, values)
+}
+
+func (enc implObjectEncoder) AddDurations(key string, values []time.Duration) {
+	enc.entry.Durations(key, values)
+}
+
+func (enc implObjectEncoder) AddErrs(key string, errs []error) {
+	enc.entry.Errs(key, errs)
+}
+
+func (enc implObjectEncoder) AddFloat64s(key string, values []float64) {
+	enc.entry.Float64s(key, values)
+}
+
+func (enc implObjectEncoder) AddInts(key string, values []int) {
+	enc.entry.Ints(key, values)
+}
+
+func (enc implObjectEncoder) AddInt64s(key string, values []int64) {
+	enc.entry.Int64s(key, values)
+}
+
+func (enc implObjectEncoder) AddStrings(key string, values []string) {
+	enc.entry.Strings(key, values)
+}
+
+func (enc implObjectEncoder) AddTimes(key string, values []time.Time) {
+	enc.entry.Times(key, values)
+}
+
+// implArrayEncoder is the ArrayEncoder that appends the elements of an array to the innermost group of the log entry.
+// The elements are added as fields whose keys are ignored by arrayElementEncoder.
+type implArrayEncoder struct {
+	entry *implLogEntry
+}
+
+func (enc implArrayEncoder) AppendAny(value interface{}) {
+	enc.entry.Any("", value)
+}
+
+func (enc implArrayEncoder) AppendArray(marshaler ArrayMarshaler) {
+	enc.entry.Array("", marshaler)
+}
+
+func (enc implArrayEncoder) AppendBool(value bool) {
+	enc.entry.Bool("", value)
+}
+
+func (enc implArrayEncoder) AppendBytes(value []byte) {
+	enc.entry.Bytes("", value)
+}
+
+func (enc implArrayEncoder) AppendDuration(value time.Duration) {
+	enc.entry.Duration("", value)
+}
+
+func (enc implArrayEncoder) AppendErr(err error) {
+	enc.entry.ErrWithKey("", err)
+}
+
+func (enc implArrayEncoder) AppendFloat32(value float32) {
+	enc.entry.Float32("", value)
+}
+
+func (enc implArrayEncoder) AppendFloat64(value float64) {
+	enc.entry.Float64("", value)
+}
+
+func (enc implArrayEncoder) AppendInt(value int) {
+	enc.entry.Int("", value)
+}
+
+func (enc implArrayEncoder) AppendInt32(value int32) {
+	enc.entry.Int32("", value)
+}
+
+func (enc implArrayEncoder) AppendInt64(value int64) {
+	enc.entry.Int64("", value)
+}
+
+func (enc implArrayEncoder) AppendObject(marshaler ObjectMarshaler) {
+	enc.entry.Object("", marshaler)
+}
+
+func (enc implArrayEncoder) AppendString(value string) {
+	enc.entry.String("", value)
+}
+
+func (enc implArrayEncoder) AppendTime(value time.Time) {
+	enc.entry.Time("", value)
+}
+
+func (enc implArrayEncoder) AppendUint(value uint) {
+	enc.entry.Uint("", value)
+}
+
+func (enc implArrayEncoder) AppendUint32(value uint32) {
+	enc.entry.Uint32("", value)
+}
+
+func (enc implArrayEncoder) AppendUint64(value uint64) {
+	enc.entry.Uint64("", value)
+}
+
 type (
 	bytesBuffer struct {
 		bytes []byte
@@ -720,7 +1572,7 @@ func appendFloatFieldValue(dst []byte, value float64, bitSize int) []byte {
 	return strconv.AppendFloat(dst, value, 'f', -1, bitSize)
 }
 
-func appendCaller(dst []byte, callerSkip int, useLongCaller bool) []byte {
+func caller(callerSkip int, useLongCaller bool) (file string, line int) {
 	pc, put := getPCBuffer()
 	defer put()
 
@@ -729,22 +1581,56 @@ func appendCaller(dst []byte, callerSkip int, useLongCaller bool) []byte {
 		frame, _ = runtime.CallersFrames(pc.pc).Next()
 	}
 
-	return appendCallerFromFrame(dst, frame, useLongCaller)
+	return callerFromFrame(frame, useLongCaller)
 }
 
-// appendCallerFromFrame was split off from appendCaller in order to test different behaviors depending on the contents of the `runtime.Frame`.
-func appendCallerFromFrame(dst []byte, frame runtime.Frame, useLongCaller bool) []byte {
-	if useLongCaller {
-		dst = appendJSONEscapedString(dst, frame.File)
-	} else {
-		dst = appendJSONEscapedString(dst, extractShortPath(frame.File))
+// stacktrace returns the stack trace of the current goroutine in the following format:
+//
+//	function
+//		file:line
+//	...
+func stacktrace(callerSkip int) string {
+	pc, put := getPCBuffer()
+	defer put()
+
+	n := runtime.Callers(callerSkip, pc.pc)
+	for n == len(pc.pc) {
+		// NOTE: the stack may be deeper than the buffer, so grow the buffer and retry.
+		pc.pc = make([]uintptr, len(pc.pc)*2) //nolint:gomnd
+		n = runtime.Callers(callerSkip, pc.pc)
+	}
+	if n == 0 {
+		return ""
 	}
 
-	dst = append(dst, ':')
-	const base = 10
-	dst = strconv.AppendInt(dst, int64(frame.Line), base)
+	b, putBytes := getBytesBuffer()
+	defer putBytes()
+
+	frames := runtime.CallersFrames(pc.pc[:n])
+	for {
+		frame, more := frames.Next()
+		b.bytes = append(b.bytes, frame.Function...)
+		b.bytes = append(b.bytes, '\n', '\t')
+		b.bytes = append(b.bytes, frame.File...)
+		b.bytes = append(b.bytes, ':')
+		const base = 10
+		b.bytes = strconv.AppendInt(b.bytes, int64(frame.Line), base)
+		if !more {
+			break
+		}
+		b.bytes = append(b.bytes, '\n')
+	}
 
-	return dst
+	return string(b.bytes)
+}
+
+// callerFromFrame was split off from caller in order to test different behaviors depending on the contents of the `runtime.Frame`.
+func callerFromFrame(frame runtime.Frame, useLongCaller bool) (file string, line int) {
+	if useLongCaller {
+		return frame.File, frame.Line
+	}
+
+	return extractShortPath(frame.File), frame.Line
 }
 
 func extractShortPath(path string) string {
@@ -775,14 +1661,41 @@ func appendKey(dst []byte, key string) []byte {
 	return dst
 }
 
-func appendLevelField(dst []byte, levels map[Level]string, level Level) []byte {
-	v, ok := levels[level]
-	if !ok {
-		v = "DEBUG"
+// sprintf returns format as it is if args is empty, otherwise fmt.Sprintf(format, args...).
+func sprintf(format string, args ...interface{}) string {
+	if len(args) > 0 {
+		return fmt.Sprintf(format, args...)
 	}
+	return format
+}
 
-	dst = append(dst, '"')
-	dst = appendJSONEscapedString(dst, v)
-	dst = append(dst, '"')
-	return dst
+// levelName returns the name of the level in levels.
+// If the level has no name, it returns the name of the nearest lower level with the difference such as "INFO+4",
+// or the name of the lowest level with the difference such as "DEBUG-4" if there is no lower level.
+func levelName(levels map[Level]string, level Level) string {
+	if name, ok := levels[level]; ok {
+		return name
+	}
+
+	var (
+		lower, lowest       Level
+		hasLower, hasLowest bool
+	)
+	for l := range levels {
+		if l < level && (!hasLower || l > lower) {
+			lower, hasLower = l, true
+		}
+		if !hasLowest || l < lowest {
+			lowest, hasLowest = l, true
+		}
+	}
+
+	switch {
+	case hasLower:
+		return levels[lower] + "+" + strconv.Itoa(int(level)-int(lower))
+	case hasLowest:
+		return levels[lowest] + strconv.Itoa(int(level)-int(lowest))
+	default:
+		return strconv.Itoa(int(level))
+	}
 }
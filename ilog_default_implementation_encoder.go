@@ -0,0 +1,198 @@
+package ilog
+
+import (
+	"strconv"
+	"time"
+)
+
+// EntryHeader is the fields that every log entry of the default implementation has.
+// A field whose key is empty is not output.
+// The keys are the ones set by the Builder, such as SetLevelKey and SetMessageKey.
+type EntryHeader struct {
+	LevelKey  string
+	Level     Level
+	LevelName string
+	// NumericLevel is whether LevelName is the number of Level, which is set by UseNumericLevel. The JSON encoder outputs it as a number.
+	NumericLevel    bool
+	TimestampKey    string
+	Timestamp       time.Time
+	TimestampFormat string
+	CallerKey       string
+	CallerFile      string
+	CallerLine      int
+	MessageKey      string
+	Message         string
+}
+
+// Encoder is the interface that encodes log entries of the default implementation.
+// Each method appends the encoded bytes to dst and returns the extended buffer, so that encoders can write into pooled buffers without allocation.
+//
+// A log entry is encoded in the following order:
+//
+//	BeginEntry(dst, header)
+//	AppendKey, Append<Value>, AppendFieldDelimiter  // for each field added to the logger, then for each field added to the log entry
+//	EndEntry(dst)
+//
+// Fields are encoded when they are added to ilog.Logger or ilog.LogEntry, and the encoded bytes are concatenated when the log entry is written.
+// Therefore an Encoder must not depend on the state of previous calls, and every field must end with AppendFieldDelimiter.
+type Encoder interface {
+	// BeginEntry appends the bytes that start a log entry and the header fields of the log entry, including their field delimiters.
+	BeginEntry(dst []byte, header EntryHeader) []byte
+	// EndEntry appends the bytes that end a log entry.
+	// dst holds the whole log entry, so EndEntry can also remove the trailing field delimiter.
+	EndEntry(dst []byte) []byte
+
+	// AppendKey appends the key of a field.
+	AppendKey(dst []byte, key string) []byte
+	// AppendFieldDelimiter appends the delimiter that follows every field.
+	AppendFieldDelimiter(dst []byte) []byte
+
+	AppendNull(dst []byte) []byte
+	AppendBool(dst []byte, value bool) []byte
+	AppendBytes(dst []byte, value []byte) []byte
+	AppendDuration(dst []byte, value time.Duration) []byte
+	// AppendError appends the message of an error or a stack trace, which may have multiple lines.
+	AppendError(dst []byte, message string) []byte
+	AppendFloat32(dst []byte, value float32) []byte
+	AppendFloat64(dst []byte, value float64) []byte
+	AppendInt64(dst []byte, value int64) []byte
+	// AppendJSON appends a value that has already been encoded as JSON.
+	// The nested values added by Object, Array, Group and the slice methods such as Strings are always encoded as JSON regardless of the Encoder,
+	// and appended by AppendJSON, so the console and logfmt encoders write them as JSON values such as `key={"nested":true}`.
+	AppendJSON(dst []byte, value []byte) []byte
+	AppendString(dst []byte, value string) []byte
+	AppendTime(dst []byte, value time.Time, format string) []byte
+	AppendUint64(dst []byte, value uint64) []byte
+}
+
+// jsonEncoder is the default encoder that encodes a log entry as a single JSON object.
+type jsonEncoder struct{}
+
+// NewJSONEncoder returns a new Encoder that encodes a log entry as a single JSON object.
+// It is the default Encoder of the default implementation.
+func NewJSONEncoder() Encoder { //nolint:ireturn
+	return jsonEncoder{}
+}
+
+func (enc jsonEncoder) BeginEntry(dst []byte, header EntryHeader) []byte {
+	dst = append(dst, '{')
+
+	if len(header.LevelKey) > 0 {
+		dst = enc.AppendKey(dst, header.LevelKey)
+		if header.NumericLevel {
+			dst = enc.AppendInt64(dst, int64(header.Level))
+		} else {
+			dst = enc.AppendString(dst, header.LevelName)
+		}
+		dst = enc.AppendFieldDelimiter(dst)
+	}
+	if len(header.TimestampKey) > 0 {
+		dst = enc.AppendKey(dst, header.TimestampKey)
+		dst = enc.AppendTime(dst, header.Timestamp, header.TimestampFormat)
+		dst = enc.AppendFieldDelimiter(dst)
+	}
+	if len(header.CallerKey) > 0 {
+		dst = enc.AppendKey(dst, header.CallerKey)
+		dst = append(dst, '"')
+		dst = appendJSONEscapedString(dst, header.CallerFile)
+		dst = append(dst, ':')
+		const base = 10
+		dst = strconv.AppendInt(dst, int64(header.CallerLine), base)
+		dst = append(dst, '"')
+		dst = enc.AppendFieldDelimiter(dst)
+	}
+	if len(header.MessageKey) > 0 {
+		dst = enc.AppendKey(dst, header.MessageKey)
+		dst = enc.AppendString(dst, header.Message)
+		dst = enc.AppendFieldDelimiter(dst)
+	}
+
+	return dst
+}
+
+func (jsonEncoder) EndEntry(dst []byte) []byte {
+	return endJSON(dst, '}')
+}
+
+func (jsonEncoder) AppendKey(dst []byte, key string) []byte {
+	return appendKey(dst, key)
+}
+
+func (jsonEncoder) AppendFieldDelimiter(dst []byte) []byte {
+	return append(dst, ',')
+}
+
+func (jsonEncoder) AppendNull(dst []byte) []byte {
+	return append(dst, null...)
+}
+
+func (jsonEncoder) AppendBool(dst []byte, value bool) []byte {
+	return strconv.AppendBool(dst, value)
+}
+
+func (jsonEncoder) AppendBytes(dst []byte, value []byte) []byte {
+	dst = append(dst, '"')
+	dst = appendJSONEscapedString(dst, string(value))
+	return append(dst, '"')
+}
+
+func (enc jsonEncoder) AppendDuration(dst []byte, value time.Duration) []byte {
+	return enc.AppendString(dst, value.String())
+}
+
+func (enc jsonEncoder) AppendError(dst []byte, message string) []byte {
+	return enc.AppendString(dst, message)
+}
+
+func (jsonEncoder) AppendFloat32(dst []byte, value float32) []byte {
+	const bitSize = 32
+	return appendFloatFieldValue(dst, float64(value), bitSize)
+}
+
+func (jsonEncoder) AppendFloat64(dst []byte, value float64) []byte {
+	const bitSize = 64
+	return appendFloatFieldValue(dst, value, bitSize)
+}
+
+func (jsonEncoder) AppendInt64(dst []byte, value int64) []byte {
+	const base = 10
+	return strconv.AppendInt(dst, value, base)
+}
+
+func (jsonEncoder) AppendJSON(dst []byte, value []byte) []byte {
+	return append(dst, value...)
+}
+
+func (jsonEncoder) AppendString(dst []byte, value string) []byte {
+	dst = append(dst, '"')
+	dst = appendJSONEscapedString(dst, value)
+	return append(dst, '"')
+}
+
+func (enc jsonEncoder) AppendTime(dst []byte, value time.Time, format string) []byte {
+	return enc.AppendString(dst, value.Format(format))
+}
+
+func (jsonEncoder) AppendUint64(dst []byte, value uint64) []byte {
+	const base = 10
+	return strconv.AppendUint(dst, value, base)
+}
+
+// arrayElementEncoder is the encoder for the elements of an array. The elements are encoded as JSON without keys.
+type arrayElementEncoder struct {
+	jsonEncoder
+}
+
+func (arrayElementEncoder) AppendKey(dst []byte, _ string) []byte {
+	return dst
+}
+
+// endJSON replaces the trailing field delimiter with end, or appends end if there is no field.
+func endJSON(dst []byte, end byte) []byte {
+	if dst[len(dst)-1] == ',' {
+		dst[len(dst)-1] = end
+		return dst
+	}
+
+	return append(dst, end)
+}
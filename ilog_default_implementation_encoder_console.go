@@ -0,0 +1,249 @@
+package ilog
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ansiColorReset   = "\x1b[0m"
+	ansiColorRed     = "\x1b[31m"
+	ansiColorYellow  = "\x1b[33m"
+	ansiColorBlue    = "\x1b[34m"
+	ansiColorMagenta = "\x1b[35m"
+)
+
+// consoleEncoder is the encoder that encodes a log entry as a human-readable line such as `TIMESTAMP LEVEL caller message key=value ...`.
+type consoleEncoder struct {
+	useColor bool
+	// levelWidth is the length of the longest level name, which is used to align the level names.
+	levelWidth int
+}
+
+// NewConsoleEncoder returns a new Encoder that encodes a log entry as a human-readable line such as `TIMESTAMP LEVEL caller message key=value ...`.
+// If useColor is true, the level names are colored with ANSI escape sequences.
+func NewConsoleEncoder(useColor bool) Encoder { //nolint:ireturn
+	return &consoleEncoder{useColor: useColor}
+}
+
+// withLevels returns a copy of the encoder whose level names are aligned to the longest name of levels.
+func (enc *consoleEncoder) withLevels(levels map[Level]string) *consoleEncoder {
+	copied := *enc
+	copied.levelWidth = 0
+	for _, name := range levels {
+		if n := utf8.RuneCountInString(name); n > copied.levelWidth {
+			copied.levelWidth = n
+		}
+	}
+
+	return &copied
+}
+
+// BeginEntry appends the timestamp, level, caller and message.
+// The message is appended as it is unless it contains control characters such as newlines, in which case it is quoted and escaped like a value so that a log entry is always one line.
+func (enc *consoleEncoder) BeginEntry(dst []byte, header EntryHeader) []byte {
+	if len(header.TimestampKey) > 0 {
+		dst = header.Timestamp.AppendFormat(dst, header.TimestampFormat)
+		dst = enc.AppendFieldDelimiter(dst)
+	}
+	if len(header.LevelKey) > 0 {
+		if enc.useColor {
+			dst = append(dst, levelColor(header.Level)...)
+			dst = append(dst, header.LevelName...)
+			dst = append(dst, ansiColorReset...)
+		} else {
+			dst = append(dst, header.LevelName...)
+		}
+		for i := utf8.RuneCountInString(header.LevelName); i < enc.levelWidth; i++ {
+			dst = append(dst, ' ')
+		}
+		dst = enc.AppendFieldDelimiter(dst)
+	}
+	if len(header.CallerKey) > 0 {
+		dst = append(dst, header.CallerFile...)
+		dst = append(dst, ':')
+		const base = 10
+		dst = strconv.AppendInt(dst, int64(header.CallerLine), base)
+		dst = enc.AppendFieldDelimiter(dst)
+	}
+	if len(header.MessageKey) > 0 {
+		if hasControlCharacter(header.Message) {
+			dst = appendTextValue(dst, header.Message)
+		} else {
+			dst = append(dst, header.Message...)
+		}
+		dst = enc.AppendFieldDelimiter(dst)
+	}
+
+	return dst
+}
+
+func (*consoleEncoder) EndEntry(dst []byte) []byte {
+	if len(dst) > 0 && dst[len(dst)-1] == ' ' {
+		return dst[:len(dst)-1]
+	}
+
+	return dst
+}
+
+// AppendKey appends the key of a field.
+// The key is quoted and escaped in the same way as a value if it contains spaces, quotation marks, equals signs or control characters.
+func (*consoleEncoder) AppendKey(dst []byte, key string) []byte {
+	dst = appendTextValue(dst, key)
+	return append(dst, '=')
+}
+
+func (*consoleEncoder) AppendFieldDelimiter(dst []byte) []byte {
+	return append(dst, ' ')
+}
+
+func (*consoleEncoder) AppendNull(dst []byte) []byte {
+	return append(dst, null...)
+}
+
+func (*consoleEncoder) AppendBool(dst []byte, value bool) []byte {
+	return strconv.AppendBool(dst, value)
+}
+
+func (*consoleEncoder) AppendBytes(dst []byte, value []byte) []byte {
+	return appendTextValue(dst, string(value))
+}
+
+func (*consoleEncoder) AppendDuration(dst []byte, value time.Duration) []byte {
+	return append(dst, value.String()...)
+}
+
+// AppendError appends the message of an error.
+// If the message has multiple lines, such as an error formatted with a stack trace, the second and subsequent lines are rendered on their own lines indented with a tab.
+// The control characters in those lines other than tabs, such as escape sequences and carriage returns, are escaped.
+func (*consoleEncoder) AppendError(dst []byte, message string) []byte {
+	idx := strings.IndexByte(message, '\n')
+	if idx == -1 {
+		return appendTextValue(dst, message)
+	}
+
+	dst = appendTextValue(dst, message[:idx])
+	rest := strings.TrimRight(message[idx+1:], "\n")
+	for {
+		dst = append(dst, '\n', '\t')
+		idx = strings.IndexByte(rest, '\n')
+		if idx == -1 {
+			return appendEscapedLine(dst, rest)
+		}
+		dst = appendEscapedLine(dst, rest[:idx])
+		rest = rest[idx+1:]
+	}
+}
+
+func (*consoleEncoder) AppendFloat32(dst []byte, value float32) []byte {
+	const bitSize = 32
+	return appendTextFloatValue(dst, float64(value), bitSize)
+}
+
+func (*consoleEncoder) AppendFloat64(dst []byte, value float64) []byte {
+	const bitSize = 64
+	return appendTextFloatValue(dst, value, bitSize)
+}
+
+func (*consoleEncoder) AppendInt64(dst []byte, value int64) []byte {
+	const base = 10
+	return strconv.AppendInt(dst, value, base)
+}
+
+func (*consoleEncoder) AppendJSON(dst []byte, value []byte) []byte {
+	return append(dst, value...)
+}
+
+func (*consoleEncoder) AppendString(dst []byte, value string) []byte {
+	return appendTextValue(dst, value)
+}
+
+func (*consoleEncoder) AppendTime(dst []byte, value time.Time, format string) []byte {
+	return appendTextValue(dst, value.Format(format))
+}
+
+func (*consoleEncoder) AppendUint64(dst []byte, value uint64) []byte {
+	const base = 10
+	return strconv.AppendUint(dst, value, base)
+}
+
+func levelColor(level Level) string {
+	switch {
+	case level >= ErrorLevel:
+		return ansiColorRed
+	case level >= WarnLevel:
+		return ansiColorYellow
+	case level >= InfoLevel:
+		return ansiColorBlue
+	default:
+		return ansiColorMagenta
+	}
+}
+
+// appendTextValue appends the value of a `key=value` pair.
+// The value is quoted only if it is empty or contains spaces, quotation marks, equals signs or control characters.
+func appendTextValue(dst []byte, value string) []byte {
+	if !needsQuoting(value) {
+		return append(dst, value...)
+	}
+
+	dst = append(dst, '"')
+	dst = appendJSONEscapedString(dst, value)
+	return append(dst, '"')
+}
+
+func needsQuoting(s string) bool {
+	if len(s) == 0 {
+		return true
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] <= ' ' || s[i] == '"' || s[i] == '=' || s[i] == 0x7F {
+			return true
+		}
+	}
+
+	return false
+}
+
+// appendEscapedLine appends the line with the control characters other than tabs escaped, without quoting it.
+func appendEscapedLine(dst []byte, line string) []byte {
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\t' || (c >= ' ' && c != 0x7F):
+			dst = append(dst, c)
+		case c == 0x7F:
+			dst = append(dst, `\u007f`...)
+		default:
+			dst = appendJSONEscapedString(dst, line[i:i+1])
+		}
+	}
+
+	return dst
+}
+
+func hasControlCharacter(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < ' ' || s[i] == 0x7F {
+			return true
+		}
+	}
+
+	return false
+}
+
+func appendTextFloatValue(dst []byte, value float64, bitSize int) []byte {
+	switch {
+	case math.IsNaN(value):
+		return append(dst, "NaN"...)
+	case math.IsInf(value, 1):
+		return append(dst, "+Inf"...)
+	case math.IsInf(value, -1):
+		return append(dst, "-Inf"...)
+	}
+
+	return strconv.AppendFloat(dst, value, 'f', -1, bitSize)
+}
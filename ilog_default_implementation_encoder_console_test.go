@@ -0,0 +1,197 @@
+package ilog //nolint:testpackage
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestUseConsoleFormat(t *testing.T) {
+	t.Parallel()
+	t.Run("success,console", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		expected := regexp.MustCompilePOSIX(`^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}\.?[0-9]*Z DEBUG ilog\.go/[a-z_]+_test\.go:[0-9]+ Logf: format string logger=logger bool=true bytes=bytes time\.Duration=1h1m1\.001001001s error="unexpected EOF" errNull=null float32=1\.234567 float64NaN=NaN float64\+Inf=\+Inf int=-1 int32=-1 int64=-1 string="a b" stringEmpty="" stringEscaped="\\"\\n" time\.Time=2023-08-13T04:38:39Z uint=1 uint32=1 uint64=1 json={"json":true}
+$`)
+
+		l := NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampZone(time.UTC).
+			UseConsoleFormat(false).
+			Build().
+			String("logger", "logger").
+			Logger()
+
+		l.
+			Bool("bool", true).
+			Bytes("bytes", []byte("bytes")).
+			Duration("time.Duration", time.Hour+time.Minute+time.Second+time.Millisecond+time.Microsecond+time.Nanosecond).
+			Err(io.ErrUnexpectedEOF).
+			ErrWithKey("errNull", nil).
+			Float32("float32", 1.234567).
+			Float64("float64NaN", math.NaN()).
+			Float64("float64+Inf", math.Inf(1)).
+			Int("int", -1).
+			Int32("int32", -1).
+			Int64("int64", -1).
+			String("string", "a b").
+			String("stringEmpty", "").
+			String("stringEscaped", "\"\n").
+			Time("time.Time", time.Date(2023, 8, 13, 4, 38, 39, 0, time.UTC)).
+			Uint("uint", 1).
+			Uint32("uint32", 1).
+			Uint64("uint64", 1).
+			Any("json", &testJSONMarshaler{MockMarshalJSON: func() ([]byte, error) { return []byte(`{"json":true}`), nil }}).
+			Logf(DebugLevel, "Logf: %s", "format string")
+
+		if !expected.Match(buf.Bytes()) {
+			t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+		}
+	})
+
+	t.Run("success,levels", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = "DEBUG   Debugf\n" +
+			"INFO    Infof key=value\n" +
+			"WARNING Warnf\n" +
+			"ERROR   Errorf\n"
+
+		l := NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			SetLevels(map[Level]string{DebugLevel: "DEBUG", InfoLevel: "INFO", WarnLevel: "WARNING", ErrorLevel: "ERROR"}).
+			UseConsoleFormat(false).
+			Build()
+		l.Debugf("Debugf")
+		l.String("key", "value").Infof("Infof")
+		l.Warnf("Warnf")
+		l.Errorf("Errorf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,color", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = "\x1b[35mDEBUG\x1b[0m Debugf\n" +
+			"\x1b[34mINFO\x1b[0m  Infof\n" +
+			"\x1b[33mWARN\x1b[0m  Warnf\n" +
+			"\x1b[31mERROR\x1b[0m Errorf\n"
+
+		l := NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			UseConsoleFormat(true).
+			Build()
+		l.Debugf("Debugf")
+		l.Infof("Infof")
+		l.Warnf("Warnf")
+		l.Errorf("Errorf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,multiLineError", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = "ERROR Errorf error=\"first line\"\n" +
+			"\tsecond line\n" +
+			"\tthird line key=value\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			UseConsoleFormat(false).
+			Build().
+			Err(errors.New("first line\nsecond line\nthird line\n")).
+			String("key", "value").
+			Errorf("Errorf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,nested", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		// NOTE: nested values are encoded as JSON regardless of the Encoder.
+		const expected = `INFO  Infof object={"key":"value"} array=[1,"two"] strings=["a","b"] group={"string":"group","int":1}` + "\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			UseConsoleFormat(false).
+			Build().
+			Object("object", testObjectMarshaler{}).
+			Array("array", ArrayMarshalerFunc(func(enc ArrayEncoder) error { enc.AppendInt(1); enc.AppendString("two"); return nil })).
+			Strings("strings", []string{"a", "b"}).
+			Group("group").String("string", "group").Int("int", 1).
+			Infof("Infof")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,escape", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `INFO  "first line\nsecond line" "key\nwith newline"=value "key with space"=value` + "\n" +
+			`INFO  message with "quotes" and spaces key=value` + "\n"
+
+		l := NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			UseConsoleFormat(false).
+			Build()
+		l.String("key\nwith newline", "value").String("key with space", "value").Infof("first line\nsecond line")
+		l.String("key", "value").Infof(`message with "quotes" and spaces`)
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,multiLineError,escape", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = "ERROR Errorf error=\"first line\"\n" +
+			"\t\\u001b[31msecond\\r line\n" +
+			"\t\tthird line\\u007f\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			UseConsoleFormat(false).
+			Build().
+			Err(errors.New("first line\n\x1b[31msecond\r line\n\tthird line\x7f")).
+			Errorf("Errorf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package ilog
+
+import (
+	"strconv"
+	"time"
+)
+
+// logfmtEncoder is the encoder that encodes a log entry as a logfmt line such as `severity=INFO timestamp=... message="..." key=value`.
+//
+// cf. https://brandur.org/logfmt
+type logfmtEncoder struct{}
+
+// NewLogfmtEncoder returns a new Encoder that encodes a log entry as a logfmt line.
+func NewLogfmtEncoder() Encoder { //nolint:ireturn
+	return logfmtEncoder{}
+}
+
+func (enc logfmtEncoder) BeginEntry(dst []byte, header EntryHeader) []byte {
+	if len(header.LevelKey) > 0 {
+		dst = enc.AppendKey(dst, header.LevelKey)
+		dst = enc.AppendString(dst, header.LevelName)
+		dst = enc.AppendFieldDelimiter(dst)
+	}
+	if len(header.TimestampKey) > 0 {
+		dst = enc.AppendKey(dst, header.TimestampKey)
+		dst = enc.AppendTime(dst, header.Timestamp, header.TimestampFormat)
+		dst = enc.AppendFieldDelimiter(dst)
+	}
+	if len(header.CallerKey) > 0 {
+		dst = enc.AppendKey(dst, header.CallerKey)
+		quote := needsQuoting(header.CallerFile)
+		if quote {
+			dst = append(dst, '"')
+		}
+		dst = appendJSONEscapedString(dst, header.CallerFile)
+		dst = append(dst, ':')
+		const base = 10
+		dst = strconv.AppendInt(dst, int64(header.CallerLine), base)
+		if quote {
+			dst = append(dst, '"')
+		}
+		dst = enc.AppendFieldDelimiter(dst)
+	}
+	if len(header.MessageKey) > 0 {
+		dst = enc.AppendKey(dst, header.MessageKey)
+		dst = enc.AppendString(dst, header.Message)
+		dst = enc.AppendFieldDelimiter(dst)
+	}
+
+	return dst
+}
+
+func (logfmtEncoder) EndEntry(dst []byte) []byte {
+	if len(dst) > 0 && dst[len(dst)-1] == ' ' {
+		return dst[:len(dst)-1]
+	}
+
+	return dst
+}
+
+// AppendKey appends the key of a field.
+// Since logfmt keys cannot contain spaces, quotation marks, equals signs and control characters, they are replaced with underscores.
+func (logfmtEncoder) AppendKey(dst []byte, key string) []byte {
+	for i := 0; i < len(key); i++ {
+		if key[i] <= ' ' || key[i] == '"' || key[i] == '=' || key[i] == 0x7F {
+			dst = append(dst, '_')
+			continue
+		}
+		dst = append(dst, key[i])
+	}
+
+	return append(dst, '=')
+}
+
+func (logfmtEncoder) AppendFieldDelimiter(dst []byte) []byte {
+	return append(dst, ' ')
+}
+
+func (logfmtEncoder) AppendNull(dst []byte) []byte {
+	return append(dst, null...)
+}
+
+func (logfmtEncoder) AppendBool(dst []byte, value bool) []byte {
+	return strconv.AppendBool(dst, value)
+}
+
+func (logfmtEncoder) AppendBytes(dst []byte, value []byte) []byte {
+	return appendTextValue(dst, string(value))
+}
+
+func (logfmtEncoder) AppendDuration(dst []byte, value time.Duration) []byte {
+	return append(dst, value.String()...)
+}
+
+func (logfmtEncoder) AppendError(dst []byte, message string) []byte {
+	return appendTextValue(dst, message)
+}
+
+func (logfmtEncoder) AppendFloat32(dst []byte, value float32) []byte {
+	const bitSize = 32
+	return appendTextFloatValue(dst, float64(value), bitSize)
+}
+
+func (logfmtEncoder) AppendFloat64(dst []byte, value float64) []byte {
+	const bitSize = 64
+	return appendTextFloatValue(dst, value, bitSize)
+}
+
+func (logfmtEncoder) AppendInt64(dst []byte, value int64) []byte {
+	const base = 10
+	return strconv.AppendInt(dst, value, base)
+}
+
+func (logfmtEncoder) AppendJSON(dst []byte, value []byte) []byte {
+	return appendTextValue(dst, string(value))
+}
+
+func (logfmtEncoder) AppendString(dst []byte, value string) []byte {
+	return appendTextValue(dst, value)
+}
+
+func (logfmtEncoder) AppendTime(dst []byte, value time.Time, format string) []byte {
+	return appendTextValue(dst, value.Format(format))
+}
+
+func (logfmtEncoder) AppendUint64(dst []byte, value uint64) []byte {
+	const base = 10
+	return strconv.AppendUint(dst, value, base)
+}
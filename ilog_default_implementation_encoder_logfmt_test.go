@@ -0,0 +1,129 @@
+package ilog //nolint:testpackage
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestUseLogfmtFormat(t *testing.T) {
+	t.Parallel()
+	t.Run("success,logfmt", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		expected := regexp.MustCompilePOSIX(`^severity=DEBUG timestamp=[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}\.?[0-9]*Z caller=ilog\.go/[a-z_]+_test\.go:[0-9]+ message="Logf: format string" logger=logger bool=true bytes=bytes time\.Duration=1h1m1\.001001001s error="unexpected EOF" errNull=null float32=1\.234567 float64NaN=NaN float64\+Inf=\+Inf int=-1 int32=-1 int64=-1 string="a b" stringEmpty="" stringEscaped="\\"\\n=" key_with_spaces=value time\.Time=2023-08-13T04:38:39Z uint=1 uint32=1 uint64=1 json="{\\"json\\":true}"
+$`)
+
+		l := NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampZone(time.UTC).
+			UseLogfmtFormat().
+			Build().
+			String("logger", "logger").
+			Logger()
+
+		l.
+			Bool("bool", true).
+			Bytes("bytes", []byte("bytes")).
+			Duration("time.Duration", time.Hour+time.Minute+time.Second+time.Millisecond+time.Microsecond+time.Nanosecond).
+			Err(io.ErrUnexpectedEOF).
+			ErrWithKey("errNull", nil).
+			Float32("float32", 1.234567).
+			Float64("float64NaN", math.NaN()).
+			Float64("float64+Inf", math.Inf(1)).
+			Int("int", -1).
+			Int32("int32", -1).
+			Int64("int64", -1).
+			String("string", "a b").
+			String("stringEmpty", "").
+			String("stringEscaped", "\"\n=").
+			String("key with spaces", "value").
+			Time("time.Time", time.Date(2023, 8, 13, 4, 38, 39, 0, time.UTC)).
+			Uint("uint", 1).
+			Uint32("uint32", 1).
+			Uint64("uint64", 1).
+			Any("json", &testJSONMarshaler{MockMarshalJSON: func() ([]byte, error) { return []byte(`{"json":true}`), nil }}).
+			Logf(DebugLevel, "Logf: %s", "format string")
+
+		if !expected.Match(buf.Bytes()) {
+			t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+		}
+	})
+
+	t.Run("success,keys", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = "level=INFO msg=Infof key=value\n" +
+			"level=WARN\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetLevelKey("level").
+			SetTimestampKey("").
+			SetCallerKey("").
+			SetMessageKey("msg").
+			UseLogfmtFormat().
+			Build().
+			String("key", "value").
+			Infof("Infof")
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetLevelKey("level").
+			SetTimestampKey("").
+			SetCallerKey("").
+			SetMessageKey("").
+			UseLogfmtFormat().
+			Build().
+			Warnf("Warnf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,nested", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		// NOTE: nested values are encoded as JSON regardless of the Encoder, and quoted as logfmt values.
+		const expected = `severity=INFO message=Infof object="{\"key\":\"value\"}" array="[1,\"two\"]" strings="[\"a\",\"b\"]" group="{\"string\":\"group\",\"int\":1}"` + "\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			UseLogfmtFormat().
+			Build().
+			Object("object", testObjectMarshaler{}).
+			Array("array", ArrayMarshalerFunc(func(enc ArrayEncoder) error { enc.AppendInt(1); enc.AppendString("two"); return nil })).
+			Strings("strings", []string{"a", "b"}).
+			Group("group").String("string", "group").Int("int", 1).
+			Infof("Infof")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+}
+
+func TestLogfmtEncoder_BeginEntry(t *testing.T) {
+	t.Parallel()
+	const expected = `severity=INFO caller="path to/file.go:1" message=message `
+	actual := logfmtEncoder{}.BeginEntry(nil, EntryHeader{
+		LevelKey:   "severity",
+		Level:      InfoLevel,
+		LevelName:  "INFO",
+		CallerKey:  "caller",
+		CallerFile: "path to/file.go",
+		CallerLine: 1,
+		MessageKey: "message",
+		Message:    "message",
+	})
+	if expected != string(actual) {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
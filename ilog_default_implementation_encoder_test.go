@@ -0,0 +1,86 @@
+package ilog //nolint:testpackage
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testUpperKeyEncoder struct {
+	Encoder
+}
+
+func (enc testUpperKeyEncoder) AppendKey(dst []byte, key string) []byte {
+	return enc.Encoder.AppendKey(dst, strings.ToUpper(key))
+}
+
+func TestSetEncoder(t *testing.T) {
+	t.Parallel()
+	t.Run("success,custom", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `{"severity":"INFO","message":"Infof","LOGGER":"logger","KEY":"value"}` + "\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			SetEncoder(testUpperKeyEncoder{NewJSONEncoder()}).
+			Build().
+			String("logger", "logger").
+			Logger().
+			String("key", "value").
+			Infof("Infof")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,builtin", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `{"severity":"INFO","message":"Infof","key":"value"}` + "\n" +
+			`severity=INFO message=Infof key=value` + "\n" +
+			`INFO  Infof key=value` + "\n"
+
+		for _, enc := range []Encoder{NewJSONEncoder(), NewLogfmtEncoder(), NewConsoleEncoder(false)} {
+			NewBuilder(DebugLevel, NewSyncWriter(buf)).
+				SetTimestampKey("").
+				SetCallerKey("").
+				SetEncoder(enc).
+				Build().
+				String("key", "value").
+				Infof("Infof")
+		}
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+}
+
+func TestJSONEncoder_BeginEntry(t *testing.T) {
+	t.Parallel()
+	const expected = `{"severity":"INFO","timestamp":"2023-08-13T04:38:39Z","caller":"path/to/file.go:1","message":"message",`
+	actual := NewJSONEncoder().BeginEntry(nil, EntryHeader{
+		LevelKey:        "severity",
+		Level:           InfoLevel,
+		LevelName:       "INFO",
+		TimestampKey:    "timestamp",
+		Timestamp:       time.Date(2023, 8, 13, 4, 38, 39, 0, time.UTC),
+		TimestampFormat: time.RFC3339Nano,
+		CallerKey:       "caller",
+		CallerFile:      "path/to/file.go",
+		CallerLine:      1,
+		MessageKey:      "message",
+		Message:         "message",
+	})
+	if expected != string(actual) {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
@@ -0,0 +1,62 @@
+package ilog
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// errorObject is the ObjectMarshaler that outputs an error as a structured object.
+type errorObject struct {
+	err error
+}
+
+func (o errorObject) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddString("message", o.err.Error())
+	enc.AddString("type", fmt.Sprintf("%T", o.err))
+	if stack := errorStackTrace(o.err); len(stack) > 0 {
+		enc.AddString("stacktrace", stack)
+	}
+	if causes := unwrapErrors(o.err); len(causes) > 0 {
+		enc.AddArray("causes", errorObjects(causes))
+	}
+	return nil
+}
+
+// errorObjects is the ArrayMarshaler that outputs errors as structured objects.
+type errorObjects []error
+
+func (errs errorObjects) MarshalLogArray(enc ArrayEncoder) error {
+	for _, err := range errs {
+		if err == nil {
+			enc.AppendErr(nil)
+			continue
+		}
+		enc.AppendObject(errorObject{err: err})
+	}
+	return nil
+}
+
+// unwrapErrors returns the errors wrapped by err.
+func unwrapErrors(err error) []error {
+	if u, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint
+		return u.Unwrap()
+	}
+	if cause := errors.Unwrap(err); cause != nil {
+		return []error{cause}
+	}
+	return nil
+}
+
+// errorStackTrace returns the stack trace of err if err has `StackTrace()` like github.com/pkg/errors.
+// Since the type of the stack trace differs for each package, the method is called via reflection and the result is formatted with "%+v".
+func errorStackTrace(err error) string {
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 {
+		return ""
+	}
+
+	// NOTE: github.com/pkg/errors formats each frame as "\nfunction\n\tfile:line".
+	return strings.TrimLeft(fmt.Sprintf("%+v", method.Call(nil)[0].Interface()), "\n")
+}
@@ -0,0 +1,76 @@
+package ilog //nolint:testpackage
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"testing"
+)
+
+type testJoinError []error
+
+func (errs testJoinError) Error() string { return "join" }
+
+func (errs testJoinError) Unwrap() []error { return errs }
+
+type testStackTrace []string
+
+func (st testStackTrace) Format(s fmt.State, verb rune) {
+	for _, f := range st {
+		_, _ = fmt.Fprintf(s, "\n%s\n\tfile.go:1", f)
+	}
+}
+
+type testStackTraceError struct{}
+
+func (testStackTraceError) Error() string { return "stack" }
+
+func (testStackTraceError) StackTrace() testStackTrace { return testStackTrace{"main.f", "main.main"} }
+
+func TestUseStructuredError(t *testing.T) {
+	t.Parallel()
+	t.Run("success,structured", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `{"severity":"ERROR","message":"Errorf",` +
+			`"error":{"message":"wrap: unexpected EOF","type":"*fmt.wrapError","causes":[{"message":"unexpected EOF","type":"*errors.errorString"}]},` +
+			`"join":{"message":"join","type":"ilog.testJoinError","causes":[{"message":"unexpected EOF","type":"*errors.errorString"},null,{"message":"stack","type":"ilog.testStackTraceError","stacktrace":"main.f\n\tfile.go:1\nmain.main\n\tfile.go:1"}]},` +
+			`"null":null,"nilPointer":null}` + "\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			UseStructuredError(true).
+			Build().
+			Err(fmt.Errorf("wrap: %w", io.ErrUnexpectedEOF)).
+			ErrWithKey("join", testJoinError{io.ErrUnexpectedEOF, nil, testStackTraceError{}}).
+			ErrWithKey("null", nil).
+			ErrWithKey("nilPointer", (*testFormatterError)(nil)).
+			Errorf("Errorf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,default", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `{"severity":"ERROR","message":"Errorf","error":"wrap: unexpected EOF"}` + "\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			Build().
+			Err(fmt.Errorf("wrap: %w", io.ErrUnexpectedEOF)).
+			Errorf("Errorf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package ilog
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const samplerCounters = 4096
+
+// Sampler limits the number of log entries that have the same level and message in each interval.
+// In each interval, the first log entries are written, and then every thereafter-th log entry is written.
+// The message is the format string passed to the logging method, so the entries are counted before they are encoded.
+//
+// The counters are shared by the loggers built from the same configuration, and the entries with different levels or messages
+// may share a counter in rare cases because they are hashed into a fixed number of counters, like go.uber.org/zap.
+type Sampler struct {
+	counts     [samplerCounters]samplerCounter
+	sampled    uint64
+	dropped    uint64
+	interval   time.Duration
+	first      uint64
+	thereafter uint64
+	hook       func(level Level, message string)
+}
+
+type samplerCounter struct {
+	resetAt int64
+	count   uint64
+}
+
+// NewSampler returns a new Sampler that writes the first log entries and every thereafter-th log entry after that in each interval.
+// If thereafter is 0, all log entries after the first ones are dropped until the interval elapses.
+func NewSampler(interval time.Duration, first, thereafter int) *Sampler {
+	if first < 0 {
+		first = 0
+	}
+	if thereafter < 0 {
+		thereafter = 0
+	}
+
+	return &Sampler{
+		interval:   interval,
+		first:      uint64(first),
+		thereafter: uint64(thereafter),
+	}
+}
+
+// SetDropHook sets the function that is called when the sampler starts dropping the log entries of a level and message in an interval.
+// It must be called before the sampler is used by loggers.
+func (s *Sampler) SetDropHook(hook func(level Level, message string)) *Sampler {
+	s.hook = hook
+	return s
+}
+
+// Sampled returns the number of log entries that have been written by the sampler.
+func (s *Sampler) Sampled() uint64 {
+	return atomic.LoadUint64(&s.sampled)
+}
+
+// Dropped returns the number of log entries that have been dropped by the sampler.
+func (s *Sampler) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+// sample reports whether the log entry that has the level and message should be written.
+func (s *Sampler) sample(level Level, message string, now time.Time) bool {
+	n := s.counts[samplerKey(level, message)%samplerCounters].incCheckReset(now, s.interval)
+	if n <= s.first || (s.thereafter > 0 && (n-s.first)%s.thereafter == 0) {
+		atomic.AddUint64(&s.sampled, 1)
+		return true
+	}
+
+	atomic.AddUint64(&s.dropped, 1)
+	if n == s.first+1 && s.hook != nil {
+		s.hook(level, message)
+	}
+	return false
+}
+
+// incCheckReset increments the counter and returns the incremented value, or resets it to 1 if the interval has elapsed.
+func (c *samplerCounter) incCheckReset(now time.Time, interval time.Duration) uint64 {
+	nano := now.UnixNano()
+	resetAt := atomic.LoadInt64(&c.resetAt)
+	if resetAt > nano {
+		return atomic.AddUint64(&c.count, 1)
+	}
+
+	atomic.StoreUint64(&c.count, 1)
+	if !atomic.CompareAndSwapInt64(&c.resetAt, resetAt, nano+interval.Nanoseconds()) {
+		// NOTE: another goroutine has reset the counter.
+		return atomic.AddUint64(&c.count, 1)
+	}
+	return 1
+}
+
+// samplerKey returns the FNV-1a hash of the level and message.
+func samplerKey(level Level, message string) uint32 {
+	const (
+		offset32 = 2166136261
+		prime32  = 16777619
+	)
+	hash := uint32(offset32)
+	hash ^= uint32(uint8(level))
+	hash *= prime32
+	for i := 0; i < len(message); i++ {
+		hash ^= uint32(message[i])
+		hash *= prime32
+	}
+	return hash
+}
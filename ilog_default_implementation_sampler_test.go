@@ -0,0 +1,101 @@
+package ilog //nolint:testpackage
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetSampler(t *testing.T) {
+	t.Parallel()
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		var hooked []string
+		sampler := NewSampler(time.Hour, 2, 3).SetDropHook(func(level Level, message string) {
+			hooked = append(hooked, levelName(defaultLevels, level)+" "+message)
+		})
+		l := NewBuilder(InfoLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			SetSampler(sampler).
+			Build()
+
+		for i := 0; i < 10; i++ {
+			l.Int("i", i).Infof("Infof")
+			l.Int("i", i).Debugf("Debugf")
+		}
+		l.Warnf("Infof")
+
+		const expected = `{"severity":"INFO","message":"Infof","i":0}` + "\n" +
+			`{"severity":"INFO","message":"Infof","i":1}` + "\n" +
+			`{"severity":"INFO","message":"Infof","i":4}` + "\n" +
+			`{"severity":"INFO","message":"Infof","i":7}` + "\n" +
+			`{"severity":"WARN","message":"Infof"}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+		if expected, actual := uint64(5), sampler.Sampled(); expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+		if expected, actual := uint64(6), sampler.Dropped(); expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+		if expected, actual := "INFO Infof", strings.Join(hooked, ","); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,thereafter=0", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		sampler := NewSampler(time.Hour, 1, 0)
+		l := NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			SetSampler(sampler).
+			Build().
+			String("key", "value").
+			Logger()
+
+		for i := 0; i < 3; i++ {
+			l.Infof("Infof")
+		}
+
+		const expected = `{"severity":"INFO","message":"Infof","key":"value"}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+		if expected, actual := uint64(2), sampler.Dropped(); expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+	})
+}
+
+func TestSampler_sample(t *testing.T) {
+	t.Parallel()
+	t.Run("success,reset", func(t *testing.T) {
+		t.Parallel()
+		s := NewSampler(time.Second, 1, 0)
+		now := time.Date(2023, 8, 13, 4, 38, 39, 0, time.UTC)
+
+		for _, tt := range []struct {
+			now      time.Time
+			expected bool
+		}{
+			{now, true},
+			{now.Add(time.Second - 1), false},
+			{now.Add(time.Second), true},
+			{now.Add(time.Second), false},
+		} {
+			if actual := s.sample(InfoLevel, "message", tt.now); tt.expected != actual {
+				t.Errorf("❌: now=%s: expected(%t) != actual(%t)", tt.now, tt.expected, actual)
+			}
+		}
+	})
+}
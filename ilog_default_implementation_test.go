@@ -8,6 +8,7 @@ import (
 	"math"
 	"path"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 )
@@ -173,6 +174,18 @@ func TestLogger(t *testing.T) {
 		l.Uint("uint", uint(1)).Debugf("Debugf")
 		l.Uint32("uint32", uint32(123456789)).Debugf("Debugf")
 		l.Uint64("uint64", uint64(123456789)).Debugf("Debugf")
+		l.Object("object", testObjectMarshaler{}).Debugf("Debugf")
+		l.Group("group").Debugf("Debugf")
+		l.Array("array", ArrayMarshalerFunc(func(enc ArrayEncoder) error { enc.AppendString("a"); return nil })).Debugf("Debugf")
+		l.Bools("bools", []bool{true, false}).Debugf("Debugf")
+		l.Durations("durations", []time.Duration{time.Second}).Debugf("Debugf")
+		l.Errs("errs", []error{io.ErrUnexpectedEOF, nil}).Debugf("Debugf")
+		l.Float64s("float64s", []float64{1.5, math.NaN()}).Debugf("Debugf")
+		l.Ints("ints", []int{-1, 1}).Debugf("Debugf")
+		l.Int64s("int64s", []int64{-1, 1}).Debugf("Debugf")
+		l.Strings("strings", []string{"a", "b"}).Debugf("Debugf")
+		l.Strings("stringsNull", nil).Debugf("Debugf")
+		l.Times("times", []time.Time{time.Date(2023, 8, 13, 4, 38, 39, 0, time.UTC)}).Debugf("Debugf")
 		l.Debugf("Debugf")
 		l.Infof("Infof")
 		l.Warnf("Warnf")
@@ -201,6 +214,18 @@ func TestLogger(t *testing.T) {
 {"severity":"DEBUG","message":"Debugf","uint":1}
 {"severity":"DEBUG","message":"Debugf","uint32":123456789}
 {"severity":"DEBUG","message":"Debugf","uint64":123456789}
+{"severity":"DEBUG","message":"Debugf","object":{"key":"value"}}
+{"severity":"DEBUG","message":"Debugf","group":{}}
+{"severity":"DEBUG","message":"Debugf","array":["a"]}
+{"severity":"DEBUG","message":"Debugf","bools":[true,false]}
+{"severity":"DEBUG","message":"Debugf","durations":["1s"]}
+{"severity":"DEBUG","message":"Debugf","errs":["unexpected EOF",null]}
+{"severity":"DEBUG","message":"Debugf","float64s":[1.5,"NaN"]}
+{"severity":"DEBUG","message":"Debugf","ints":[-1,1]}
+{"severity":"DEBUG","message":"Debugf","int64s":[-1,1]}
+{"severity":"DEBUG","message":"Debugf","strings":["a","b"]}
+{"severity":"DEBUG","message":"Debugf","stringsNull":null}
+{"severity":"DEBUG","message":"Debugf","times":["2023-08-13T04:38:39Z"]}
 {"severity":"DEBUG","message":"Debugf"}
 {"severity":"INFO","message":"Infof"}
 {"severity":"WARN","message":"Warnf"}
@@ -227,6 +252,13 @@ func (m *testJSONMarshaler) MarshalJSON() ([]byte, error) {
 	return m.MockMarshalJSON()
 }
 
+type testObjectMarshaler struct{}
+
+func (testObjectMarshaler) MarshalLogObject(enc ObjectEncoder) error {
+	enc.AddString("key", "value")
+	return nil
+}
+
 func TestLogEntry(t *testing.T) {
 	t.Parallel()
 	t.Run("success,LogEntry", func(t *testing.T) {
@@ -287,12 +319,12 @@ func TestLogEntry(t *testing.T) {
 		}
 	})
 
-	t.Run("success,default,DEBUG", func(t *testing.T) {
+	t.Run("success,default,lowerThanTRACE", func(t *testing.T) {
 		t.Parallel()
 		buf := bytes.NewBuffer(nil)
 		defer t.Logf("ℹ️: buf:\n%s", buf)
 
-		expected := regexp.MustCompilePOSIX(`{"severity":"DEBUG","timestamp":"[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}\.?[0-9]*Z","caller":"ilog\.go/[a-z_]+_test\.go:[0-9]+","message":"default"}`)
+		expected := regexp.MustCompilePOSIX(`{"severity":"TRACE-112","timestamp":"[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}\.?[0-9]*Z","caller":"ilog\.go/[a-z_]+_test\.go:[0-9]+","message":"default"}`)
 
 		NewBuilder(-128, NewSyncWriter(buf)).SetTimestampZone(time.UTC).Build().Logf(-128, "default")
 
@@ -358,6 +390,358 @@ func TestLogEntry(t *testing.T) {
 	})
 }
 
+func TestLogEntry_Object(t *testing.T) {
+	t.Parallel()
+	t.Run("success,Object", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `{"severity":"DEBUG","message":"Debugf","object":{"any":["a"],"bool":true,"bytes":"bytes","time.Duration":"1s","error":"unexpected EOF","float32":1.5,"float64":1.5,"int":-1,"int32":-1,"int64":-1,"nested":{"key":"value"},"string":"string","time.Time":"2023-08-13T04:38:39Z","uint":1,"uint32":1,"uint64":1},"key":"value"}` + "\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			Build().
+			Object("object", ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+				enc.AddAny("any", []string{"a"})
+				enc.AddBool("bool", true)
+				enc.AddBytes("bytes", []byte("bytes"))
+				enc.AddDuration("time.Duration", time.Second)
+				enc.AddErr("error", io.ErrUnexpectedEOF)
+				enc.AddFloat32("float32", 1.5)
+				enc.AddFloat64("float64", 1.5)
+				enc.AddInt("int", -1)
+				enc.AddInt32("int32", -1)
+				enc.AddInt64("int64", -1)
+				enc.AddObject("nested", testObjectMarshaler{})
+				enc.AddString("string", "string")
+				enc.AddTime("time.Time", time.Date(2023, 8, 13, 4, 38, 39, 0, time.UTC))
+				enc.AddUint("uint", 1)
+				enc.AddUint32("uint32", 1)
+				enc.AddUint64("uint64", 1)
+				return nil
+			})).
+			String("key", "value").
+			Debugf("Debugf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,error", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `{"severity":"DEBUG","message":"Debugf","object":{"key":"value"},"objectError":"unexpected EOF","objectNull":null}` + "\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			Build().
+			Object("object", ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+				enc.AddString("key", "value")
+				return io.ErrUnexpectedEOF
+			})).
+			Object("objectNull", (ObjectMarshalerFunc)(nil)).
+			Debugf("Debugf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,logfmt", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `severity=DEBUG message=Debugf object="{\"key\":\"value\"}"` + "\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			UseLogfmtFormat().
+			Build().
+			Object("object", testObjectMarshaler{}).
+			Debugf("Debugf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+}
+
+func TestLogEntry_Array(t *testing.T) {
+	t.Parallel()
+	t.Run("success,Array", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `{"severity":"DEBUG","message":"Debugf","array":[["a"],{"a":1},true,"bytes","1s","unexpected EOF",null,1.5,1.5,-1,-1,-1,"string","2023-08-13T04:38:39Z",1,1,1],"empty":[],"any":["a","b"],"key":"value"}` + "\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			Build().
+			Array("array", ArrayMarshalerFunc(func(enc ArrayEncoder) error {
+				enc.AppendArray(ArrayMarshalerFunc(func(enc ArrayEncoder) error {
+					enc.AppendAny("a")
+					return nil
+				}))
+				enc.AppendObject(ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+					enc.AddInt("a", 1)
+					return nil
+				}))
+				enc.AppendBool(true)
+				enc.AppendBytes([]byte("bytes"))
+				enc.AppendDuration(time.Second)
+				enc.AppendErr(io.ErrUnexpectedEOF)
+				enc.AppendErr(nil)
+				enc.AppendFloat32(1.5)
+				enc.AppendFloat64(1.5)
+				enc.AppendInt(-1)
+				enc.AppendInt32(-1)
+				enc.AppendInt64(-1)
+				enc.AppendString("string")
+				enc.AppendTime(time.Date(2023, 8, 13, 4, 38, 39, 0, time.UTC))
+				enc.AppendUint(1)
+				enc.AppendUint32(1)
+				enc.AppendUint64(1)
+				return nil
+			})).
+			Array("empty", ArrayMarshalerFunc(func(enc ArrayEncoder) error { return nil })).
+			Any("any", []string{"a", "b"}).
+			String("key", "value").
+			Debugf("Debugf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,error", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `{"severity":"DEBUG","message":"Debugf","array":["a"],"arrayError":"unexpected EOF","arrayNull":null}` + "\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			Build().
+			Array("array", ArrayMarshalerFunc(func(enc ArrayEncoder) error {
+				enc.AppendString("a")
+				return io.ErrUnexpectedEOF
+			})).
+			Array("arrayNull", (ArrayMarshalerFunc)(nil)).
+			Debugf("Debugf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,ObjectEncoder", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `{"severity":"DEBUG","message":"Debugf","object":{"array":[1],"bools":[true],"durations":["1s"],"errs":["unexpected EOF"],"float64s":[1.5],"ints":[1],"int64s":[1],"strings":["a"],"times":["2023-08-13T04:38:39Z"]}}` + "\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			Build().
+			Object("object", ObjectMarshalerFunc(func(enc ObjectEncoder) error {
+				enc.AddArray("array", ArrayMarshalerFunc(func(enc ArrayEncoder) error { enc.AppendInt(1); return nil }))
+				enc.AddBools("bools", []bool{true})
+				enc.AddDurations("durations", []time.Duration{time.Second})
+				enc.AddErrs("errs", []error{io.ErrUnexpectedEOF})
+				enc.AddFloat64s("float64s", []float64{1.5})
+				enc.AddInts("ints", []int{1})
+				enc.AddInt64s("int64s", []int64{1})
+				enc.AddStrings("strings", []string{"a"})
+				enc.AddTimes("times", []time.Time{time.Date(2023, 8, 13, 4, 38, 39, 0, time.UTC)})
+				return nil
+			})).
+			Debugf("Debugf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,console", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `DEBUG Debugf strings=["a","b c"]` + "\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			UseConsoleFormat(false).
+			Build().
+			Strings("strings", []string{"a", "b c"}).
+			Debugf("Debugf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+}
+
+func TestLogEntry_Stack(t *testing.T) {
+	t.Parallel()
+	t.Run("success,Stack", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		expected := regexp.MustCompilePOSIX(`^{"severity":"DEBUG","message":"Logger","stack":"github\.com/kunitsucom/ilog(\.|%2e)go\.TestLogEntry_Stack\.func1\\n\\t[^"]*ilog_default_implementation_test\.go:[0-9]+\\n[^"]+"}
+{"severity":"DEBUG","message":"LogEntry","key":"value","stack":"github\.com/kunitsucom/ilog(\.|%2e)go\.TestLogEntry_Stack\.func1\\n\\t[^"]*ilog_default_implementation_test\.go:[0-9]+\\n[^"]+"}
+$`)
+
+		l := NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			Build()
+		l.Stack("stack").Debugf("Logger")
+		l.String("key", "value").Stack("stack").Debugf("LogEntry")
+
+		if !expected.Match(buf.Bytes()) {
+			t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+		}
+	})
+
+	t.Run("success,SetStacktraceKey", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		expected := regexp.MustCompilePOSIX(`^{"severity":"INFO","message":"Infof"}
+{"severity":"WARN","message":"Warnf","key":"value","stacktrace":"github\.com/kunitsucom/ilog(\.|%2e)go\.TestLogEntry_Stack\.func2\\n\\t[^"]*ilog_default_implementation_test\.go:[0-9]+\\n[^"]+"}
+{"severity":"ERROR","message":"Errorf","stacktrace":"github\.com/kunitsucom/ilog(\.|%2e)go\.TestLogEntry_Stack\.func2\\n\\t[^"]*ilog_default_implementation_test\.go:[0-9]+\\n[^"]+"}
+$`)
+
+		l := NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			SetStacktraceKey("stacktrace").
+			SetStacktraceLevel(WarnLevel).
+			Build()
+		l.Infof("Infof")
+		l.String("key", "value").Warnf("Warnf")
+		l.Errorf("Errorf")
+
+		if !expected.Match(buf.Bytes()) {
+			t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+		}
+	})
+
+	t.Run("success,console", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		expected := regexp.MustCompilePOSIX(`^ERROR Errorf stacktrace=github\.com/kunitsucom/ilog(\.|%2e)go\.TestLogEntry_Stack\.func3
+		[^ ]*ilog_default_implementation_test\.go:[0-9]+
+	[^ ]+
+`)
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			SetStacktraceKey("stacktrace").
+			UseConsoleFormat(false).
+			Build().
+			Errorf("Errorf")
+
+		if !expected.Match(buf.Bytes()) {
+			t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+		}
+	})
+}
+
+func Test_stacktrace(t *testing.T) {
+	t.Parallel()
+	t.Run("success,deep", func(t *testing.T) {
+		t.Parallel()
+		var recurse func(depth int) string
+		recurse = func(depth int) string {
+			if depth == 0 {
+				return stacktrace(1)
+			}
+			return recurse(depth - 1)
+		}
+
+		const depth = 100
+		actual := recurse(depth)
+		if n := strings.Count(actual, "Test_stacktrace.func1.1\n"); n <= depth {
+			t.Errorf("❌: the stack trace is truncated: n=%d:\n%s", n, actual)
+		}
+	})
+}
+
+func TestLogEntry_Group(t *testing.T) {
+	t.Parallel()
+	t.Run("success,Group", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `{"severity":"DEBUG","message":"Debugf","key":"value","request":{"method":"GET","response":{"status":200}}}` + "\n"
+
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			Build().
+			String("key", "value").
+			Group("request").
+			String("method", "GET").
+			Group("response").
+			Int("status", 200).
+			Debugf("Debugf")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,Logger", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `{"severity":"DEBUG","message":"first","key":"value","group":{"logger":"logger","entry":"first"}}` + "\n" +
+			`{"severity":"DEBUG","message":"second","key":"value","group":{"logger":"logger","object":{"key":"value"}}}` + "\n" +
+			`{"severity":"DEBUG","message":"third","key":"value","group":{"logger":"logger"}}` + "\n"
+
+		l := NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			Build().
+			String("key", "value").
+			Group("group").
+			String("logger", "logger").
+			Logger()
+
+		l.String("entry", "first").Debugf("first")
+		l.Object("object", testObjectMarshaler{}).Debugf("second")
+		l.Debugf("third")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+}
+
 func TestLogger_logf(t *testing.T) {
 	t.Parallel()
 	t.Run("success,empty", func(t *testing.T) {
@@ -480,3 +864,278 @@ func Test_extractShortPath(t *testing.T) {
 		}
 	})
 }
+
+func TestAddHook(t *testing.T) {
+	t.Parallel()
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		var entries []HookEntry
+		hook := HookFunc(func(entry HookEntry) { entries = append(entries, entry) })
+		l := NewBuilder(InfoLevel, NewSyncWriter(buf)).
+			SetTimestampKey("").
+			SetCallerKey("").
+			SetMessageKey("").
+			AddHook(hook, hook).
+			Build().
+			String("logger", "logger").
+			Logger()
+
+		l.Debugf("Debugf")
+		l.Int("int", 1).Object("object", testObjectMarshaler{}).Errorf("Errorf: %s", "arg")
+
+		if expected, actual := 2, len(entries); expected != actual {
+			t.Fatalf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+		entry := entries[0]
+		if expected, actual := ErrorLevel, entry.Level; expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+		if expected, actual := "Errorf: arg", entry.Message; expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+		if entry.Timestamp.IsZero() {
+			t.Errorf("❌: entry.Timestamp is zero")
+		}
+		if expected, actual := "ilog_default_implementation_test.go", entry.CallerFile; !strings.HasSuffix(actual, expected) {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+		if expected, actual := `map[int:1 logger:logger object:map[key:value]]`, fmt.Sprint(entry.Fields); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,UseLogfmtFormat", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		var entries []HookEntry
+		l := NewBuilder(DebugLevel, NewSyncWriter(buf)).
+			UseLogfmtFormat().
+			AddHook(HookFunc(func(entry HookEntry) { entries = append(entries, entry) })).
+			Build().
+			Named("app").
+			Group("group").
+			String("key", "value").
+			Logger()
+		l.Duration("duration", time.Second).
+			Err(io.EOF).
+			Strings("strings", []string{"a"}).
+			Array("array", ArrayMarshalerFunc(func(enc ArrayEncoder) error {
+				enc.AppendObject(testObjectMarshaler{})
+				return nil
+			})).
+			Any("any", nil).
+			Infof("Infof: %s", "message\nwith newline")
+
+		if expected, actual := 1, len(entries); expected != actual {
+			t.Fatalf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+		if expected, actual := `map[group:map[any:<nil> array:[map[key:value]] duration:1s error:EOF key:value strings:[a]] logger:app]`, fmt.Sprint(entries[0].Fields); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+		if expected, actual := io.EOF, entries[0].Fields["group"].(map[string]interface{})["error"]; expected != actual { //nolint:forcetypeassert
+			t.Errorf("❌: expected(%v) != actual(%v)", expected, actual)
+		}
+	})
+}
+
+func TestSetLevelVar(t *testing.T) {
+	t.Parallel()
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		v := NewLevelVar(InfoLevel)
+		l := NewBuilder(ErrorLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").SetLevelVar(v).Build()
+		copies := []Logger{l, l.Copy(), l.AddCallerSkip(0), l.String("key", "value").Logger()}
+
+		for _, l := range copies {
+			l.Debugf("Debugf")
+		}
+		v.Set(DebugLevel)
+		for _, l := range copies {
+			l.Debugf("Debugf")
+		}
+		// NOTE: SetLevel returns a copy that no longer reads the LevelVar, so the others are not changed.
+		warn := copies[1].SetLevel(WarnLevel)
+		warn.Infof("Infof")
+		v.Set(TraceLevel)
+		warn.Debugf("Debugf")
+		copies[0].Infof("Infof")
+		_, _ = copies[2].Write([]byte("Write"))
+
+		const expected = `{"severity":"DEBUG","message":"Debugf"}` + "\n" +
+			`{"severity":"DEBUG","message":"Debugf"}` + "\n" +
+			`{"severity":"DEBUG","message":"Debugf"}` + "\n" +
+			`{"severity":"DEBUG","message":"Debugf","key":"value"}` + "\n" +
+			`{"severity":"INFO","message":"Infof"}` + "\n" +
+			`{"severity":"TRACE","message":"Write"}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+		if expected, actual := TraceLevel, v.Level(); expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+		if expected, actual := WarnLevel, warn.Level(); expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+	})
+}
+
+func TestLogger_Named(t *testing.T) {
+	t.Parallel()
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		l := NewBuilder(DebugLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").Build()
+		l.Named("db").Named("pool").String("key", "value").Infof("Infof")
+		l.Named("").Infof("Infof")
+		l.Named("http").Copy().Infof("Infof")
+
+		const expected = `{"severity":"INFO","message":"Infof","logger":"db.pool","key":"value"}` + "\n" +
+			`{"severity":"INFO","message":"Infof"}` + "\n" +
+			`{"severity":"INFO","message":"Infof","logger":"http"}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,SetNameKey", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		l := NewBuilder(DebugLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").SetNameKey("").Build()
+		l.Named("db").Infof("Infof")
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").SetNameKey("name").Build().Named("db").Infof("Infof")
+
+		const expected = `{"severity":"INFO","message":"Infof"}` + "\n" +
+			`{"severity":"INFO","message":"Infof","name":"db"}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,SetLevelRegistry", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		r, err := ParseLevelRegistry("db.*=DEBUG,http=ERROR")
+		if err != nil {
+			t.Fatalf("❌: err: %v", err)
+		}
+		l := NewBuilder(InfoLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").SetLevelRegistry(r).Build()
+		db, pool, http := l.Named("db"), l.Named("db").Named("pool"), l.Named("http")
+
+		db.Debugf("db")
+		pool.Debugf("pool")
+		http.Warnf("http")
+		r.Set("db.*", InfoLevel)
+		pool.Debugf("pool")
+		http.Errorf("http")
+
+		const expected = `{"severity":"DEBUG","message":"pool","logger":"db.pool"}` + "\n" +
+			`{"severity":"ERROR","message":"http","logger":"http"}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+}
+
+//nolint:paralleltest
+func TestLogger_Fatalf(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	defer t.Logf("ℹ️: buf:\n%s", buf)
+
+	var codes []int
+	defer SetExitFunc(func(code int) { codes = append(codes, code) })()
+
+	l := NewBuilder(TraceLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").Build()
+	l.Tracef("Tracef")
+	l.String("key", "value").Tracef("Tracef")
+	l.Fatalf("Fatalf: %s", "arg")
+	l.String("key", "value").Fatalf("Fatalf")
+	l.Logf(FatalLevel, "Logf")
+	l.SetLevel(ErrorLevel).Tracef("Tracef")
+
+	panics := make([]interface{}, 0)
+	for _, panicf := range []func(string, ...interface{}){l.Panicf, l.String("key", "value").Panicf} {
+		func() {
+			defer func() { panics = append(panics, recover()) }()
+			panicf("Panicf: %d", 1)
+		}()
+	}
+
+	const expected = `{"severity":"TRACE","message":"Tracef"}` + "\n" +
+		`{"severity":"TRACE","message":"Tracef","key":"value"}` + "\n" +
+		`{"severity":"FATAL","message":"Fatalf: arg"}` + "\n" +
+		`{"severity":"FATAL","message":"Fatalf","key":"value"}` + "\n" +
+		`{"severity":"FATAL","message":"Logf"}` + "\n" +
+		`{"severity":"PANIC","message":"Panicf: 1"}` + "\n" +
+		`{"severity":"PANIC","message":"Panicf: 1","key":"value"}` + "\n"
+	if actual := buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+	if expected, actual := "[1 1]", fmt.Sprint(codes); expected != actual {
+		t.Errorf("❌: expected(%s) != actual(%s)", expected, actual)
+	}
+	if expected, actual := "[Panicf: 1 Panicf: 1]", fmt.Sprint(panics); expected != actual {
+		t.Errorf("❌: expected(%s) != actual(%s)", expected, actual)
+	}
+}
+
+func TestLogger_levelName(t *testing.T) {
+	t.Parallel()
+	t.Run("success,interpolated", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		l := NewBuilder(TraceLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").Build()
+		l.Logf(4, "Logf")
+		l.Logf(-12, "Logf")
+		l.SetLevel(-128).Logf(-128, "Logf")
+		l = NewBuilder(DebugLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").SetLevels(map[Level]string{InfoLevel: "info", 4: "notice"}).Build()
+		l.Logf(6, "Logf")
+		l.Debugf("Debugf")
+		NewBuilder(DebugLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").SetLevels(map[Level]string{}).Build().Infof("Infof")
+
+		const expected = `{"severity":"INFO+4","message":"Logf"}` + "\n" +
+			`{"severity":"TRACE+4","message":"Logf"}` + "\n" +
+			`{"severity":"TRACE-112","message":"Logf"}` + "\n" +
+			`{"severity":"notice+2","message":"Logf"}` + "\n" +
+			`{"severity":"info-8","message":"Debugf"}` + "\n" +
+			`{"severity":"0","message":"Infof"}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,UseNumericLevel", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		b := NewBuilder(DebugLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").UseNumericLevel(true)
+		b.Build().Warnf("Warnf")
+		b.Build().Debugf("Debugf")
+		b.UseLogfmtFormat().Build().Warnf("Warnf")
+		b.UseConsoleFormat(false).Build().Warnf("Warnf")
+
+		const expected = `{"severity":8,"message":"Warnf"}` + "\n" +
+			`{"severity":-8,"message":"Debugf"}` + "\n" +
+			`severity=8 message=Warnf` + "\n" +
+			`8     Warnf` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+}
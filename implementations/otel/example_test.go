@@ -0,0 +1,368 @@
+package otel_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kunitsucom/ilog.go"
+	ilogotel "github.com/kunitsucom/ilog.go/implementations/otel"
+)
+
+func testSpanContext(t *testing.T) trace.SpanContext {
+	t.Helper()
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	if err != nil {
+		t.Fatalf("❌: err: %v", err)
+	}
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	if err != nil {
+		t.Fatalf("❌: err: %v", err)
+	}
+	return trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled})
+}
+
+func TestNewTraceExtractor(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		opts     []ilogotel.TraceOption
+		expected string
+	}{
+		{"success,OTel", nil, `{"severity":"INFO","message":"Infof","key":"value","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","span_id":"00f067aa0ba902b7","trace_flags":"01"}`},
+		{"success,WithTraceKeys", []ilogotel.TraceOption{ilogotel.WithTraceKeys("traceId", "spanId", "")}, `{"severity":"INFO","message":"Infof","key":"value","traceId":"4bf92f3577b34da6a3ce929d0e0e4736","spanId":"00f067aa0ba902b7"}`},
+		{"success,WithGCP", []ilogotel.TraceOption{ilogotel.WithGCP("my-project")}, `{"severity":"INFO","message":"Infof","key":"value","logging.googleapis.com/trace":"projects/my-project/traces/4bf92f3577b34da6a3ce929d0e0e4736","logging.googleapis.com/spanId":"00f067aa0ba902b7","logging.googleapis.com/trace_sampled":true}`},
+		{"success,WithDatadog", []ilogotel.TraceOption{ilogotel.WithDatadog()}, `{"severity":"INFO","message":"Infof","key":"value","dd.trace_id":"11803532876627986230","dd.span_id":"67667974448284343"}`},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			buf := bytes.NewBuffer(nil)
+			l := ilog.NewBuilder(ilog.DebugLevel, ilog.NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").Build()
+			ctx := trace.ContextWithSpanContext(context.Background(), testSpanContext(t))
+
+			extract := ilogotel.NewTraceExtractor(tt.opts...)
+			extract(ctx, l.String("key", "value")).Infof("Infof")
+			extract(context.Background(), l.String("key", "value")).Infof("Infof")
+
+			if expected, actual := tt.expected+"\n"+`{"severity":"INFO","message":"Infof","key":"value"}`+"\n", buf.String(); expected != actual {
+				t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSampler(sdktrace.AlwaysSample()))
+	defer func() { _ = tp.Shutdown(context.Background()) }()
+
+	ctx := ilog.WithContext(context.Background(), ilog.NewBuilder(ilog.DebugLevel, ilog.NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").Build())
+	ilogotel.FromContext(ctx).Infof("no span")
+	ctx, span := tp.Tracer("test").Start(ctx, "span")
+	defer span.End()
+	ilogotel.FromContext(ctx, ilogotel.WithTraceKeys("trace_id", "span_id", "")).Infof("span")
+
+	sc := span.SpanContext()
+	expected := `{"severity":"INFO","message":"no span"}` + "\n" +
+		`{"severity":"INFO","message":"span","trace_id":"` + sc.TraceID().String() + `","span_id":"` + sc.SpanID().String() + `"}` + "\n"
+	if actual := buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+// testExporter is the in-memory sdklog.Exporter that records the exported log records.
+type testExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *testExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (*testExporter) Shutdown(context.Context) error { return nil }
+
+func (*testExporter) ForceFlush(context.Context) error { return nil }
+
+// String returns the exported log records such as `INFO(9) "message" key:value`, one per line.
+// The caller attributes are replaced with "code.filepath:FILE" and "code.lineno:LINE" if the caller is example_test.go.
+func (e *testExporter) String() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var b strings.Builder
+	for _, r := range e.records {
+		fmt.Fprintf(&b, "%s(%d) %q", r.SeverityText(), r.Severity(), r.Body().AsString())
+		r.WalkAttributes(func(kv log.KeyValue) bool {
+			switch {
+			case kv.Key == "code.filepath" && strings.HasSuffix(kv.Value.AsString(), "/example_test.go"):
+				b.WriteString(" code.filepath:FILE")
+			case kv.Key == "code.lineno" && kv.Value.AsInt64() > 0:
+				b.WriteString(" code.lineno:LINE")
+			default:
+				b.WriteString(" " + kv.String())
+			}
+			return true
+		})
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func newTestLogger(level ilog.Level) (ilog.Logger, *testExporter) {
+	exporter := &testExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	return ilogotel.New(level, provider.Logger("test")), exporter
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	l, exporter := newTestLogger(ilog.DebugLevel)
+	l = l.Any("any", "any").
+		Bool("bool", true).
+		Bytes("bytes", []byte("bytes")).
+		Duration("duration", time.Hour+time.Minute+time.Second).
+		Err(io.ErrUnexpectedEOF).
+		ErrWithKey("err", nil).
+		Float32("float32", 1.5).
+		Float64("float64", 1.1).
+		Int("int", 1).
+		Int32("int32", 1).
+		Int64("int64", 1).
+		String("string", "string").
+		Time("time", time.Date(2023, 8, 13, 4, 38, 39, 123456789, time.FixedZone("Asia/Tokyo", int(9*time.Hour/time.Second)))).
+		Uint("uint", 1).
+		Uint32("uint32", 1).
+		Uint64("uint64", 1<<63).
+		Object("object", ilog.ObjectMarshalerFunc(func(enc ilog.ObjectEncoder) error {
+			enc.AddString("string", "string")
+			enc.AddObject("nested", ilog.ObjectMarshalerFunc(func(enc ilog.ObjectEncoder) error {
+				enc.AddInt("int", 1)
+				return nil
+			}))
+			return nil
+		})).
+		Array("array", ilog.ArrayMarshalerFunc(func(enc ilog.ArrayEncoder) error {
+			enc.AppendString("string")
+			enc.AppendInt(1)
+			return nil
+		})).
+		Bools("bools", []bool{true}).
+		Durations("durations", []time.Duration{time.Second}).
+		Errs("errs", []error{io.ErrUnexpectedEOF, nil}).
+		Float64s("float64s", []float64{1.1}).
+		Ints("ints", []int{1}).
+		Int64s("int64s", []int64{1}).
+		Strings("strings", nil).
+		Times("times", []time.Time{time.Date(2023, 8, 13, 4, 38, 39, 123456789, time.UTC)}).
+		Logger()
+
+	l = l.Group("group").String("string", "group").Logger()
+
+	l.String("key", "new logger").Debugf("debug %s", "message")
+	l.Infof("info message")
+
+	const attrs = `any:any bool:true bytes:bytes duration:1h1m1s error:unexpected EOF err:<nil> float32:1.5 float64:1.1 int:1 int32:1 int64:1 string:string time:2023-08-13T04:38:39.123456789+09:00 uint:1 uint32:1 uint64:9223372036854775808 object:[string:string nested:[int:1]] array:[string 1] bools:[true] durations:[1s] errs:[unexpected EOF <nil>] float64s:[1.1] ints:[1] int64s:[1] strings:[] times:[2023-08-13T04:38:39.123456789Z]`
+	const expected = `DEBUG(5) "debug message" code.filepath:FILE code.lineno:LINE ` + attrs + ` group:[string:group key:new logger]` + "\n" +
+		`INFO(9) "info message" code.filepath:FILE code.lineno:LINE ` + attrs + ` group:[string:group]` + "\n"
+	if actual := exporter.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+func TestLevel(t *testing.T) {
+	t.Parallel()
+	l, exporter := newTestLogger(ilog.TraceLevel)
+	l.Tracef("Tracef")
+	l.Debugf("Debugf")
+	l.Infof("Infof")
+	l.Warnf("Warnf")
+	l.Errorf("Errorf")
+	l.Logf(ilog.ErrorLevel+2, "Logf")
+	l.Logf(ilog.PanicLevel, "Logf")
+	l.Logf(ilog.FatalLevel, "Logf")
+	l.SetLevel(ilog.TraceLevel-8).Logf(ilog.TraceLevel-8, "Logf")
+	l.SetLevel(ilog.InfoLevel).Debugf("Debugf")
+	_, _ = l.SetLevel(ilog.WarnLevel).Write([]byte("Write"))
+
+	const expected = `TRACE(1) "Tracef" code.filepath:FILE code.lineno:LINE` + "\n" +
+		`DEBUG(5) "Debugf" code.filepath:FILE code.lineno:LINE` + "\n" +
+		`INFO(9) "Infof" code.filepath:FILE code.lineno:LINE` + "\n" +
+		`WARN(13) "Warnf" code.filepath:FILE code.lineno:LINE` + "\n" +
+		`ERROR(17) "Errorf" code.filepath:FILE code.lineno:LINE` + "\n" +
+		`ERROR+2(18) "Logf" code.filepath:FILE code.lineno:LINE` + "\n" +
+		`PANIC(21) "Logf" code.filepath:FILE code.lineno:LINE` + "\n" +
+		`FATAL(24) "Logf" code.filepath:FILE code.lineno:LINE` + "\n" +
+		`TRACE-8(1) "Logf" code.filepath:FILE code.lineno:LINE` + "\n" +
+		`WARN(13) "Write" code.filepath:FILE code.lineno:LINE` + "\n"
+	if actual := exporter.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+func TestFromLogger(t *testing.T) {
+	t.Parallel()
+	l, exporter := newTestLogger(ilog.DebugLevel)
+	var r log.Record
+	r.SetSeverity(log.SeverityInfo)
+	r.SetBody(log.StringValue("Emit"))
+	ilogotel.FromLogger(l.String("key", "value").Logger()).Emit(context.Background(), r)
+
+	if expected, actual := `(9) "Emit"`+"\n", exporter.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+	if ilogotel.FromLogger(ilog.NewBuilder(ilog.DebugLevel, ilog.NewSyncWriter(bytes.NewBuffer(nil))).Build()) == nil {
+		t.Errorf("❌: FromLogger: expected the logger of the global LoggerProvider")
+	}
+}
+
+func TestStack(t *testing.T) {
+	t.Parallel()
+	l, exporter := newTestLogger(ilog.DebugLevel)
+	l.Stack("stack").Debugf("Logger")
+	l.String("key", "value").Stack("stack").Debugf("LogEntry")
+
+	expected := regexp.MustCompilePOSIX(`^DEBUG\(5\) "Logger" code\.filepath:FILE code\.lineno:LINE stack:github\.com/kunitsucom/ilog\.go/implementations/otel_test\.TestStack
+	[^ ]+/example_test\.go:[0-9]+
+`)
+	if actual := exporter.String(); !expected.MatchString(actual) {
+		t.Errorf("❌: !expected.MatchString(actual):\n%s", actual)
+	}
+}
+
+// testNilObject panics in MarshalLogObject if it is a typed nil.
+type testNilObject struct{ name string }
+
+func (o *testNilObject) MarshalLogObject(enc ilog.ObjectEncoder) error {
+	enc.AddString("name", o.name)
+	return nil
+}
+
+// NOTE: a nil marshaler is written as an empty value like null of the default implementation, instead of panicking.
+func TestObject_nil(t *testing.T) {
+	t.Parallel()
+	l, exporter := newTestLogger(ilog.DebugLevel)
+	l.Object("object", nil).
+		Array("array", nil).
+		Object("typed", (*testNilObject)(nil)).
+		Object("nested", ilog.ObjectMarshalerFunc(func(enc ilog.ObjectEncoder) error {
+			enc.AddObject("object", nil)
+			enc.AddArray("array", nil)
+			return nil
+		})).
+		Array("elements", ilog.ArrayMarshalerFunc(func(enc ilog.ArrayEncoder) error {
+			enc.AppendObject(nil)
+			enc.AppendArray(nil)
+			return nil
+		})).
+		Infof("Infof")
+
+	const expected = `INFO(9) "Infof" code.filepath:FILE code.lineno:LINE object:<nil> array:<nil> typed:<nil> nested:[object:<nil> array:<nil>] elements:[<nil> <nil>]` + "\n"
+	if actual := exporter.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+func TestSetLevelVar(t *testing.T) {
+	t.Parallel()
+	l, exporter := newTestLogger(ilog.DebugLevel)
+	v := ilog.NewLevelVar(ilog.InfoLevel)
+	l = ilogotel.SetLevelVar(l, v)
+	copied := l.String("key", "value").Logger()
+
+	l.Debugf("Debugf")
+	v.Set(ilog.DebugLevel)
+	copied.Debugf("Debugf")
+	// NOTE: SetLevel does not change the LevelVar shared by the other loggers.
+	l.SetLevel(ilog.WarnLevel).Infof("hidden")
+	copied.Infof("Infof")
+
+	if expected, actual := ilog.DebugLevel, l.Level(); expected != actual {
+		t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+	}
+	if expected, actual := `DEBUG(5) "Debugf" code.filepath:FILE code.lineno:LINE key:value`+"\n"+`INFO(9) "Infof" code.filepath:FILE code.lineno:LINE key:value`+"\n", exporter.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+func TestSetLevelRegistry(t *testing.T) {
+	t.Parallel()
+	l, exporter := newTestLogger(ilog.InfoLevel)
+	r := ilog.NewLevelRegistry()
+	r.Set("db", ilog.DebugLevel)
+	l = ilogotel.SetLevelRegistry(l, r)
+
+	l.Named("db").Named("pool").Debugf("Debugf")
+	l.Named("http").Debugf("Debugf")
+
+	if expected, actual := `DEBUG(5) "Debugf" code.filepath:FILE code.lineno:LINE logger:db.pool`+"\n", exporter.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+//nolint:paralleltest
+func TestFatalf(t *testing.T) {
+	var codes []int
+	defer ilog.SetExitFunc(func(code int) { codes = append(codes, code) })()
+	l, exporter := newTestLogger(ilog.TraceLevel)
+
+	l.String("key", "value").Fatalf("Fatalf: %s", "arg")
+	func() {
+		defer func() {
+			if expected, actual := "Panicf", recover(); expected != actual {
+				t.Errorf("❌: expected(%v) != actual(%v)", expected, actual)
+			}
+		}()
+		l.Panicf("Panicf")
+	}()
+
+	const expected = `FATAL(24) "Fatalf: arg" code.filepath:FILE code.lineno:LINE key:value` + "\n" +
+		`PANIC(21) "Panicf" code.filepath:FILE code.lineno:LINE` + "\n"
+	if actual := exporter.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+	if expected, actual := "[1]", fmt.Sprint(codes); expected != actual {
+		t.Errorf("❌: expected(%s) != actual(%s)", expected, actual)
+	}
+}
+
+func TestCtx(t *testing.T) {
+	t.Parallel()
+	l, exporter := newTestLogger(ilog.DebugLevel)
+	ctx := trace.ContextWithSpanContext(context.Background(), testSpanContext(t))
+
+	l.Ctx(ctx).Infof("Infof")
+	l.String("key", "value").Ctx(nil).Infof("Infof") //nolint:staticcheck
+
+	exporter.mu.Lock()
+	defer exporter.mu.Unlock()
+	if expected, actual := 2, len(exporter.records); expected != actual {
+		t.Fatalf("❌: expected(%d) != actual(%d)", expected, actual)
+	}
+	if expected, actual := "4bf92f3577b34da6a3ce929d0e0e4736", exporter.records[0].TraceID().String(); expected != actual {
+		t.Errorf("❌: expected(%s) != actual(%s)", expected, actual)
+	}
+	if expected, actual := "00f067aa0ba902b7", exporter.records[0].SpanID().String(); expected != actual {
+		t.Errorf("❌: expected(%s) != actual(%s)", expected, actual)
+	}
+	if exporter.records[1].TraceID().IsValid() {
+		t.Errorf("❌: expected no trace ID: %s", exporter.records[1].TraceID())
+	}
+}
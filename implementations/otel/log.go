@@ -0,0 +1,1004 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+
+	"github.com/kunitsucom/ilog.go"
+)
+
+const (
+	// instrumentationName is the name of the OpenTelemetry logger returned by FromLogger if the logger is not created by New.
+	instrumentationName = "github.com/kunitsucom/ilog.go/implementations/otel"
+
+	// nameKey is the key of the logger name attribute set by Named.
+	nameKey = "logger"
+
+	// codeFilepathKey and codeLinenoKey are the keys of the caller attributes.
+	//
+	// cf. https://opentelemetry.io/docs/specs/semconv/attributes-registry/code/
+	codeFilepathKey = "code.filepath"
+	codeLinenoKey   = "code.lineno"
+)
+
+// FromLogger returns the OpenTelemetry logger that the logger created by New emits to.
+// If the logger is not created by New, it returns the logger of the global LoggerProvider.
+func FromLogger(ilogOTel ilog.Logger) log.Logger { //nolint:ireturn
+	il, ok := ilogOTel.(*implLogger)
+	if !ok {
+		return global.Logger(instrumentationName)
+	}
+	return il.logger
+}
+
+type implLogger struct {
+	level         ilog.Level
+	levelVar      *ilog.LevelVar
+	levelRegistry *ilog.LevelRegistry
+	name          string
+	logger        log.Logger
+	attrs         []log.KeyValue
+	groups        []group
+	callerSkip    int
+}
+
+// New returns ilog.Logger that emits the log records to the OpenTelemetry logger such as LoggerProvider.Logger("name").
+//
+// The levels are converted to the severity numbers, the fields to the attributes, and the caller to the "code.filepath" and "code.lineno" attributes.
+func New(level ilog.Level, logger log.Logger) ilog.Logger { //nolint:ireturn
+	const skip = 3
+	return &implLogger{
+		level:      level,
+		logger:     logger,
+		callerSkip: skip,
+	}
+}
+
+// SetLevelVar returns a copy of the logger that reads its level from the LevelVar instead of the level passed to New.
+// The LevelVar is shared by the copies of the logger, so changing it changes the level of all of them at runtime.
+func SetLevelVar(ilogOTel ilog.Logger, v *ilog.LevelVar) ilog.Logger { //nolint:ireturn
+	il, ok := ilogOTel.(*implLogger)
+	if !ok {
+		return ilogOTel
+	}
+	copied := il.copy()
+	copied.levelVar = v
+	return copied
+}
+
+func (l *implLogger) Level() ilog.Level {
+	if l.levelVar != nil {
+		return l.levelVar.Level()
+	}
+	return l.level
+}
+
+// SetLevel returns a copy of the logger with the level.
+// If the logger has a LevelVar set by SetLevelVar, the copy no longer reads it, so the level of the other loggers sharing it is not changed. To change all of them, use LevelVar.Set.
+func (l *implLogger) SetLevel(level ilog.Level) ilog.Logger { //nolint:ireturn
+	copied := l.copy()
+	copied.levelVar = nil
+	copied.level = level
+	return copied
+}
+
+func (l *implLogger) AddCallerSkip(skip int) ilog.Logger { //nolint:ireturn
+	copied := l.copy()
+	copied.callerSkip += skip
+	return copied
+}
+
+func (l *implLogger) Copy() ilog.Logger { //nolint:ireturn
+	return l.copy()
+}
+
+// SetLevelRegistry returns a copy of the logger whose descendants named by Named use the levels configured in the LevelRegistry.
+func SetLevelRegistry(ilogOTel ilog.Logger, r *ilog.LevelRegistry) ilog.Logger { //nolint:ireturn
+	il, ok := ilogOTel.(*implLogger)
+	if !ok {
+		return ilogOTel
+	}
+	copied := il.copy()
+	copied.levelRegistry = r
+	return copied
+}
+
+func (l *implLogger) Named(name string) ilog.Logger { //nolint:ireturn
+	copied := l.copy()
+	copied.name = joinName(l.name, name)
+	copied.levelVar = lookupLevelVar(copied.levelRegistry, copied.name, copied.levelVar)
+	return copied
+}
+
+func (l *implLogger) copy() *implLogger {
+	copied := *l
+	return &copied
+}
+
+func (l *implLogger) Any(key string, value interface{}) ilog.LogEntry { //nolint:ireturn
+	return l.new().Any(key, value)
+}
+
+func (l *implLogger) Bool(key string, value bool) ilog.LogEntry { //nolint:ireturn
+	return l.new().Bool(key, value)
+}
+
+func (l *implLogger) Bytes(key string, value []byte) ilog.LogEntry { //nolint:ireturn
+	return l.new().Bytes(key, value)
+}
+
+func (l *implLogger) Duration(key string, value time.Duration) ilog.LogEntry { //nolint:ireturn
+	return l.new().Duration(key, value)
+}
+
+func (l *implLogger) Err(err error) ilog.LogEntry { //nolint:ireturn
+	return l.new().Err(err)
+}
+
+func (l *implLogger) ErrWithKey(key string, err error) ilog.LogEntry { //nolint:ireturn
+	return l.new().ErrWithKey(key, err)
+}
+
+func (l *implLogger) Float32(key string, value float32) ilog.LogEntry { //nolint:ireturn
+	return l.new().Float32(key, value)
+}
+
+func (l *implLogger) Float64(key string, value float64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Float64(key, value)
+}
+
+func (l *implLogger) Int(key string, value int) ilog.LogEntry { //nolint:ireturn
+	return l.new().Int(key, value)
+}
+
+func (l *implLogger) Int32(key string, value int32) ilog.LogEntry { //nolint:ireturn
+	return l.new().Int32(key, value)
+}
+
+func (l *implLogger) Int64(key string, value int64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Int64(key, value)
+}
+
+func (l *implLogger) String(key, value string) ilog.LogEntry { //nolint:ireturn
+	return l.new().String(key, value)
+}
+
+func (l *implLogger) Time(key string, value time.Time) ilog.LogEntry { //nolint:ireturn
+	return l.new().Time(key, value)
+}
+
+func (l *implLogger) Uint(key string, value uint) ilog.LogEntry { //nolint:ireturn
+	return l.new().Uint(key, value)
+}
+
+func (l *implLogger) Uint32(key string, value uint32) ilog.LogEntry { //nolint:ireturn
+	return l.new().Uint32(key, value)
+}
+
+func (l *implLogger) Uint64(key string, value uint64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Uint64(key, value)
+}
+
+func (l *implLogger) Object(key string, marshaler ilog.ObjectMarshaler) ilog.LogEntry { //nolint:ireturn
+	return l.new().Object(key, marshaler)
+}
+
+func (l *implLogger) Group(name string) ilog.LogEntry { //nolint:ireturn
+	return l.new().Group(name)
+}
+
+func (l *implLogger) Array(key string, marshaler ilog.ArrayMarshaler) ilog.LogEntry { //nolint:ireturn
+	return l.new().Array(key, marshaler)
+}
+
+func (l *implLogger) Bools(key string, values []bool) ilog.LogEntry { //nolint:ireturn
+	return l.new().Bools(key, values)
+}
+
+func (l *implLogger) Durations(key string, values []time.Duration) ilog.LogEntry { //nolint:ireturn
+	return l.new().Durations(key, values)
+}
+
+func (l *implLogger) Errs(key string, errs []error) ilog.LogEntry { //nolint:ireturn
+	return l.new().Errs(key, errs)
+}
+
+func (l *implLogger) Float64s(key string, values []float64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Float64s(key, values)
+}
+
+func (l *implLogger) Ints(key string, values []int) ilog.LogEntry { //nolint:ireturn
+	return l.new().Ints(key, values)
+}
+
+func (l *implLogger) Int64s(key string, values []int64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Int64s(key, values)
+}
+
+func (l *implLogger) Strings(key string, values []string) ilog.LogEntry { //nolint:ireturn
+	return l.new().Strings(key, values)
+}
+
+func (l *implLogger) Times(key string, values []time.Time) ilog.LogEntry { //nolint:ireturn
+	return l.new().Times(key, values)
+}
+
+func (l *implLogger) Stack(key string) ilog.LogEntry { //nolint:ireturn
+	// NOTE: skip runtime.Callers, stacktrace and implLogger.Stack
+	const skip = 3
+	e := l.new()
+	e.add(log.String(key, stacktrace(skip)))
+	return e
+}
+
+func (l *implLogger) Ctx(ctx context.Context) ilog.LogEntry { //nolint:ireturn
+	return l.new().Ctx(ctx)
+}
+
+func (l *implLogger) Tracef(format string, args ...interface{}) {
+	l.new().logf(ilog.TraceLevel, format, args...)
+}
+
+func (l *implLogger) Debugf(format string, args ...interface{}) {
+	l.new().logf(ilog.DebugLevel, format, args...)
+}
+
+func (l *implLogger) Infof(format string, args ...interface{}) {
+	l.new().logf(ilog.InfoLevel, format, args...)
+}
+
+func (l *implLogger) Warnf(format string, args ...interface{}) {
+	l.new().logf(ilog.WarnLevel, format, args...)
+}
+
+func (l *implLogger) Errorf(format string, args ...interface{}) {
+	l.new().logf(ilog.ErrorLevel, format, args...)
+}
+
+func (l *implLogger) Panicf(format string, args ...interface{}) {
+	l.new().logf(ilog.PanicLevel, format, args...)
+	panic(sprintf(format, args...))
+}
+
+func (l *implLogger) Fatalf(format string, args ...interface{}) {
+	l.new().logf(ilog.FatalLevel, format, args...)
+	ilog.Exit(1)
+}
+
+func (l *implLogger) Logf(level ilog.Level, format string, args ...interface{}) {
+	l.new().logf(level, format, args...)
+}
+
+func (l *implLogger) Write(p []byte) (int, error) {
+	l.new().logf(l.Level(), "%s", p)
+	return len(p), nil
+}
+
+// new returns a new log entry that has the attributes of the logger.
+func (l *implLogger) new() *implLogEntry {
+	return &implLogEntry{
+		logger: l,
+		attrs:  l.attrs[:len(l.attrs):len(l.attrs)],
+		groups: cloneGroups(l.groups),
+	}
+}
+
+//nolint:errname
+type implLogEntry struct {
+	logger *implLogger
+	// ctx is the context passed to log.Logger, which is set by Ctx.
+	ctx    context.Context //nolint:containedctx
+	attrs  []log.KeyValue
+	groups []group
+}
+
+// group is a group opened by Group.
+type group struct {
+	name  string
+	attrs []log.KeyValue
+}
+
+// cloneGroups returns a copy of the groups whose attributes are capped, so that appending to the copy does not modify the original.
+func cloneGroups(groups []group) []group {
+	if len(groups) == 0 {
+		return nil
+	}
+	cloned := make([]group, len(groups))
+	for i, g := range groups {
+		cloned[i] = group{name: g.name, attrs: g.attrs[:len(g.attrs):len(g.attrs)]}
+	}
+	return cloned
+}
+
+func (*implLogEntry) Error() string {
+	return ilog.ErrLogEntryIsNotWritten.Error()
+}
+
+// add adds the attribute to the innermost open group, or to the log entry if no group is open.
+func (e *implLogEntry) add(attr log.KeyValue) {
+	if n := len(e.groups); n > 0 {
+		e.groups[n-1].attrs = append(e.groups[n-1].attrs, attr)
+		return
+	}
+	e.attrs = append(e.attrs, attr)
+}
+
+func (e *implLogEntry) Any(key string, value interface{}) ilog.LogEntry { //nolint:ireturn
+	e.add(log.KeyValue{Key: key, Value: anyValue(value)})
+	return e
+}
+
+func (e *implLogEntry) Bool(key string, value bool) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Bool(key, value))
+	return e
+}
+
+func (e *implLogEntry) Bytes(key string, value []byte) ilog.LogEntry { //nolint:ireturn
+	e.add(log.String(key, string(value)))
+	return e
+}
+
+func (e *implLogEntry) Duration(key string, value time.Duration) ilog.LogEntry { //nolint:ireturn
+	e.add(log.KeyValue{Key: key, Value: durationValue(value)})
+	return e
+}
+
+func (e *implLogEntry) Err(err error) ilog.LogEntry { //nolint:ireturn
+	return e.ErrWithKey("error", err)
+}
+
+func (e *implLogEntry) ErrWithKey(key string, err error) ilog.LogEntry { //nolint:ireturn
+	e.add(log.KeyValue{Key: key, Value: errorValue(err)})
+	return e
+}
+
+func (e *implLogEntry) Float32(key string, value float32) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Float64(key, float64(value)))
+	return e
+}
+
+func (e *implLogEntry) Float64(key string, value float64) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Float64(key, value))
+	return e
+}
+
+func (e *implLogEntry) Int(key string, value int) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Int(key, value))
+	return e
+}
+
+func (e *implLogEntry) Int32(key string, value int32) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Int64(key, int64(value)))
+	return e
+}
+
+func (e *implLogEntry) Int64(key string, value int64) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Int64(key, value))
+	return e
+}
+
+func (e *implLogEntry) String(key, value string) ilog.LogEntry { //nolint:ireturn
+	e.add(log.String(key, value))
+	return e
+}
+
+func (e *implLogEntry) Time(key string, value time.Time) ilog.LogEntry { //nolint:ireturn
+	e.add(log.KeyValue{Key: key, Value: timeValue(value)})
+	return e
+}
+
+func (e *implLogEntry) Uint(key string, value uint) ilog.LogEntry { //nolint:ireturn
+	e.add(log.KeyValue{Key: key, Value: uint64Value(uint64(value))})
+	return e
+}
+
+func (e *implLogEntry) Uint32(key string, value uint32) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Int64(key, int64(value)))
+	return e
+}
+
+func (e *implLogEntry) Uint64(key string, value uint64) ilog.LogEntry { //nolint:ireturn
+	e.add(log.KeyValue{Key: key, Value: uint64Value(value)})
+	return e
+}
+
+// Object adds the nested object as a map attribute.
+// If the marshaler returns an error, the error is added with the key suffixed by "Error".
+// If the marshaler is nil or panics, an empty value is added as the default implementation adds null.
+func (e *implLogEntry) Object(key string, marshaler ilog.ObjectMarshaler) ilog.LogEntry { //nolint:ireturn
+	enc, err := marshalObject(marshaler)
+	if enc == nil {
+		e.add(log.KeyValue{Key: key, Value: log.Value{}})
+		return e
+	}
+	e.add(log.Map(key, enc.attrs...))
+	if err != nil {
+		e.add(log.KeyValue{Key: key + "Error", Value: errorValue(err)})
+	}
+	return e
+}
+
+func (e *implLogEntry) Group(name string) ilog.LogEntry { //nolint:ireturn
+	e.groups = append(e.groups, group{name: name})
+	return e
+}
+
+// Array adds the array as a slice attribute.
+// If the marshaler returns an error, the error is added with the key suffixed by "Error".
+// If the marshaler is nil or panics, an empty value is added as the default implementation adds null.
+func (e *implLogEntry) Array(key string, marshaler ilog.ArrayMarshaler) ilog.LogEntry { //nolint:ireturn
+	enc, err := marshalArray(marshaler)
+	if enc == nil {
+		e.add(log.KeyValue{Key: key, Value: log.Value{}})
+		return e
+	}
+	e.add(log.Slice(key, enc.values...))
+	if err != nil {
+		e.add(log.KeyValue{Key: key + "Error", Value: errorValue(err)})
+	}
+	return e
+}
+
+func (e *implLogEntry) Bools(key string, values []bool) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Slice(key, sliceValues(values, log.BoolValue)...))
+	return e
+}
+
+func (e *implLogEntry) Durations(key string, values []time.Duration) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Slice(key, sliceValues(values, durationValue)...))
+	return e
+}
+
+func (e *implLogEntry) Errs(key string, errs []error) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Slice(key, sliceValues(errs, errorValue)...))
+	return e
+}
+
+func (e *implLogEntry) Float64s(key string, values []float64) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Slice(key, sliceValues(values, log.Float64Value)...))
+	return e
+}
+
+func (e *implLogEntry) Ints(key string, values []int) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Slice(key, sliceValues(values, log.IntValue)...))
+	return e
+}
+
+func (e *implLogEntry) Int64s(key string, values []int64) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Slice(key, sliceValues(values, log.Int64Value)...))
+	return e
+}
+
+func (e *implLogEntry) Strings(key string, values []string) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Slice(key, sliceValues(values, log.StringValue)...))
+	return e
+}
+
+func (e *implLogEntry) Times(key string, values []time.Time) ilog.LogEntry { //nolint:ireturn
+	e.add(log.Slice(key, sliceValues(values, timeValue)...))
+	return e
+}
+
+func (e *implLogEntry) Stack(key string) ilog.LogEntry { //nolint:ireturn
+	// NOTE: skip runtime.Callers, stacktrace and implLogEntry.Stack
+	const skip = 3
+	e.add(log.String(key, stacktrace(skip)))
+	return e
+}
+
+func (e *implLogEntry) Logger() ilog.Logger { //nolint:ireturn
+	copied := e.logger.copy()
+	copied.attrs = e.attrs[:len(e.attrs):len(e.attrs)]
+	copied.groups = cloneGroups(e.groups)
+	return copied
+}
+
+func (e *implLogEntry) Write(p []byte) (int, error) {
+	e.logf(e.logger.Level(), "%s", p)
+	return len(p), nil
+}
+
+// Ctx adds the fields extracted from ctx, and passes ctx to log.Logger so that the log record is correlated with the span in ctx.
+func (e *implLogEntry) Ctx(ctx context.Context) ilog.LogEntry { //nolint:ireturn
+	if ctx != nil {
+		e.ctx = ctx
+	}
+	return ilog.ExtractContext(ctx, e)
+}
+
+func (e *implLogEntry) Tracef(format string, args ...interface{}) {
+	e.logf(ilog.TraceLevel, format, args...)
+}
+
+func (e *implLogEntry) Debugf(format string, args ...interface{}) {
+	e.logf(ilog.DebugLevel, format, args...)
+}
+
+func (e *implLogEntry) Infof(format string, args ...interface{}) {
+	e.logf(ilog.InfoLevel, format, args...)
+}
+
+func (e *implLogEntry) Warnf(format string, args ...interface{}) {
+	e.logf(ilog.WarnLevel, format, args...)
+}
+
+func (e *implLogEntry) Errorf(format string, args ...interface{}) {
+	e.logf(ilog.ErrorLevel, format, args...)
+}
+
+func (e *implLogEntry) Panicf(format string, args ...interface{}) {
+	e.logf(ilog.PanicLevel, format, args...)
+	panic(sprintf(format, args...))
+}
+
+func (e *implLogEntry) Fatalf(format string, args ...interface{}) {
+	e.logf(ilog.FatalLevel, format, args...)
+	ilog.Exit(1)
+}
+
+func (e *implLogEntry) Logf(level ilog.Level, format string, args ...interface{}) {
+	e.logf(level, format, args...)
+}
+
+// logf emits the log record. Unlike the other implementations, it returns no error, since log.Logger.Emit returns none.
+func (e *implLogEntry) logf(level ilog.Level, format string, args ...interface{}) {
+	if level < e.logger.Level() {
+		return
+	}
+
+	ctx := e.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	severity := toSeverity(level)
+	if !e.logger.logger.Enabled(ctx, log.EnabledParameters{Severity: severity}) {
+		return
+	}
+
+	var r log.Record
+	r.SetTimestamp(time.Now())
+	r.SetSeverity(severity)
+	r.SetSeverityText(level.String())
+	r.SetBody(log.StringValue(sprintf(format, args...)))
+
+	var pcs [1]uintptr
+	if runtime.Callers(e.logger.callerSkip, pcs[:]) > 0 {
+		frame, _ := runtime.CallersFrames(pcs[:]).Next()
+		r.AddAttributes(log.String(codeFilepathKey, frame.File), log.Int(codeLinenoKey, frame.Line))
+	}
+	if len(e.logger.name) > 0 {
+		r.AddAttributes(log.String(nameKey, e.logger.name))
+	}
+	r.AddAttributes(e.attrs...)
+	if len(e.groups) > 0 {
+		r.AddAttributes(e.groupAttr(0))
+	}
+
+	e.logger.logger.Emit(ctx, r)
+}
+
+// groupAttr returns the map attribute of groups[i] which contains the attributes of the deeper groups.
+func (e *implLogEntry) groupAttr(i int) log.KeyValue {
+	attrs := e.groups[i].attrs
+	if i+1 < len(e.groups) {
+		attrs = append(attrs[:len(attrs):len(attrs)], e.groupAttr(i+1))
+	}
+	return log.Map(e.groups[i].name, attrs...)
+}
+
+// toSeverity converts ilog.Level to log.Severity.
+// The standard levels of ilog are twice as large as those of slog, so the levels are scaled in the same way as the slog implementation,
+// and then offset in the same way as the slog bridge of OpenTelemetry, e.g. DebugLevel to SeverityDebug and FatalLevel to SeverityFatal4.
+func toSeverity(level ilog.Level) log.Severity {
+	const (
+		scale  = 2
+		offset = 9
+	)
+	severity := int(math.Floor(float64(level)/scale)) + offset
+	switch {
+	case severity < int(log.SeverityTrace1):
+		return log.SeverityTrace1
+	case severity > int(log.SeverityFatal4):
+		return log.SeverityFatal4
+	default:
+		return log.Severity(severity)
+	}
+}
+
+// objectEncoder is ilog.ObjectEncoder that collects the fields of a nested object as attributes.
+type objectEncoder struct {
+	attrs []log.KeyValue
+}
+
+func (enc *objectEncoder) add(attr log.KeyValue) {
+	enc.attrs = append(enc.attrs, attr)
+}
+
+func (enc *objectEncoder) AddAny(key string, value interface{}) {
+	enc.add(log.KeyValue{Key: key, Value: anyValue(value)})
+}
+
+func (enc *objectEncoder) AddBool(key string, value bool) {
+	enc.add(log.Bool(key, value))
+}
+
+func (enc *objectEncoder) AddBytes(key string, value []byte) {
+	enc.add(log.String(key, string(value)))
+}
+
+func (enc *objectEncoder) AddDuration(key string, value time.Duration) {
+	enc.add(log.KeyValue{Key: key, Value: durationValue(value)})
+}
+
+func (enc *objectEncoder) AddErr(key string, err error) {
+	enc.add(log.KeyValue{Key: key, Value: errorValue(err)})
+}
+
+func (enc *objectEncoder) AddFloat32(key string, value float32) {
+	enc.add(log.Float64(key, float64(value)))
+}
+
+func (enc *objectEncoder) AddFloat64(key string, value float64) {
+	enc.add(log.Float64(key, value))
+}
+
+func (enc *objectEncoder) AddInt(key string, value int) {
+	enc.add(log.Int(key, value))
+}
+
+func (enc *objectEncoder) AddInt32(key string, value int32) {
+	enc.add(log.Int64(key, int64(value)))
+}
+
+func (enc *objectEncoder) AddInt64(key string, value int64) {
+	enc.add(log.Int64(key, value))
+}
+
+func (enc *objectEncoder) AddObject(key string, marshaler ilog.ObjectMarshaler) {
+	nested, err := marshalObject(marshaler)
+	if nested == nil {
+		enc.add(log.KeyValue{Key: key, Value: log.Value{}})
+		return
+	}
+	enc.add(log.Map(key, nested.attrs...))
+	if err != nil {
+		enc.add(log.KeyValue{Key: key + "Error", Value: errorValue(err)})
+	}
+}
+
+func (enc *objectEncoder) AddString(key, value string) {
+	enc.add(log.String(key, value))
+}
+
+func (enc *objectEncoder) AddTime(key string, value time.Time) {
+	enc.add(log.KeyValue{Key: key, Value: timeValue(value)})
+}
+
+func (enc *objectEncoder) AddUint(key string, value uint) {
+	enc.add(log.KeyValue{Key: key, Value: uint64Value(uint64(value))})
+}
+
+func (enc *objectEncoder) AddUint32(key string, value uint32) {
+	enc.add(log.Int64(key, int64(value)))
+}
+
+func (enc *objectEncoder) AddUint64(key string, value uint64) {
+	enc.add(log.KeyValue{Key: key, Value: uint64Value(value)})
+}
+
+func (enc *objectEncoder) AddArray(key string, marshaler ilog.ArrayMarshaler) {
+	nested, err := marshalArray(marshaler)
+	if nested == nil {
+		enc.add(log.KeyValue{Key: key, Value: log.Value{}})
+		return
+	}
+	enc.add(log.Slice(key, nested.values...))
+	if err != nil {
+		enc.add(log.KeyValue{Key: key + "Error", Value: errorValue(err)})
+	}
+}
+
+func (enc *objectEncoder) AddBools(key string, values []bool) {
+	enc.add(log.Slice(key, sliceValues(values, log.BoolValue)...))
+}
+
+func (enc *objectEncoder) AddDurations(key string, values []time.Duration) {
+	enc.add(log.Slice(key, sliceValues(values, durationValue)...))
+}
+
+func (enc *objectEncoder) AddErrs(key string, errs []error) {
+	enc.add(log.Slice(key, sliceValues(errs, errorValue)...))
+}
+
+func (enc *objectEncoder) AddFloat64s(key string, values []float64) {
+	enc.add(log.Slice(key, sliceValues(values, log.Float64Value)...))
+}
+
+func (enc *objectEncoder) AddInts(key string, values []int) {
+	enc.add(log.Slice(key, sliceValues(values, log.IntValue)...))
+}
+
+func (enc *objectEncoder) AddInt64s(key string, values []int64) {
+	enc.add(log.Slice(key, sliceValues(values, log.Int64Value)...))
+}
+
+func (enc *objectEncoder) AddStrings(key string, values []string) {
+	enc.add(log.Slice(key, sliceValues(values, log.StringValue)...))
+}
+
+func (enc *objectEncoder) AddTimes(key string, values []time.Time) {
+	enc.add(log.Slice(key, sliceValues(values, timeValue)...))
+}
+
+// marshalObject returns the objectEncoder that the marshaler added the fields to, or nil if the marshaler is nil or panics.
+func marshalObject(marshaler ilog.ObjectMarshaler) (enc *objectEncoder, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			enc, err = nil, nil
+		}
+	}()
+	// NOTE: Even if marshaler is nil, it is not judged as nil because it may have type information. Calling marshaler.MarshalLogObject() causes panic.
+	enc = &objectEncoder{}
+	return enc, marshaler.MarshalLogObject(enc)
+}
+
+// marshalArray returns the arrayEncoder that the marshaler appended the elements to, or nil if the marshaler is nil or panics.
+func marshalArray(marshaler ilog.ArrayMarshaler) (enc *arrayEncoder, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			enc, err = nil, nil
+		}
+	}()
+	enc = &arrayEncoder{}
+	return enc, marshaler.MarshalLogArray(enc)
+}
+
+// arrayEncoder is ilog.ArrayEncoder that collects the elements of an array as values.
+type arrayEncoder struct {
+	values []log.Value
+}
+
+func (enc *arrayEncoder) AppendAny(value interface{}) {
+	enc.values = append(enc.values, anyValue(value))
+}
+
+func (enc *arrayEncoder) AppendArray(marshaler ilog.ArrayMarshaler) {
+	nested, _ := marshalArray(marshaler)
+	if nested == nil {
+		enc.values = append(enc.values, log.Value{})
+		return
+	}
+	enc.values = append(enc.values, log.SliceValue(nested.values...))
+}
+
+func (enc *arrayEncoder) AppendBool(value bool) {
+	enc.values = append(enc.values, log.BoolValue(value))
+}
+
+func (enc *arrayEncoder) AppendBytes(value []byte) {
+	enc.values = append(enc.values, log.StringValue(string(value)))
+}
+
+func (enc *arrayEncoder) AppendDuration(value time.Duration) {
+	enc.values = append(enc.values, durationValue(value))
+}
+
+func (enc *arrayEncoder) AppendErr(err error) {
+	enc.values = append(enc.values, errorValue(err))
+}
+
+func (enc *arrayEncoder) AppendFloat32(value float32) {
+	enc.values = append(enc.values, log.Float64Value(float64(value)))
+}
+
+func (enc *arrayEncoder) AppendFloat64(value float64) {
+	enc.values = append(enc.values, log.Float64Value(value))
+}
+
+func (enc *arrayEncoder) AppendInt(value int) {
+	enc.values = append(enc.values, log.IntValue(value))
+}
+
+func (enc *arrayEncoder) AppendInt32(value int32) {
+	enc.values = append(enc.values, log.Int64Value(int64(value)))
+}
+
+func (enc *arrayEncoder) AppendInt64(value int64) {
+	enc.values = append(enc.values, log.Int64Value(value))
+}
+
+func (enc *arrayEncoder) AppendObject(marshaler ilog.ObjectMarshaler) {
+	nested, _ := marshalObject(marshaler)
+	if nested == nil {
+		enc.values = append(enc.values, log.Value{})
+		return
+	}
+	enc.values = append(enc.values, log.MapValue(nested.attrs...))
+}
+
+func (enc *arrayEncoder) AppendString(value string) {
+	enc.values = append(enc.values, log.StringValue(value))
+}
+
+func (enc *arrayEncoder) AppendTime(value time.Time) {
+	enc.values = append(enc.values, timeValue(value))
+}
+
+func (enc *arrayEncoder) AppendUint(value uint) {
+	enc.values = append(enc.values, uint64Value(uint64(value)))
+}
+
+func (enc *arrayEncoder) AppendUint32(value uint32) {
+	enc.values = append(enc.values, log.Int64Value(int64(value)))
+}
+
+func (enc *arrayEncoder) AppendUint64(value uint64) {
+	enc.values = append(enc.values, uint64Value(value))
+}
+
+// sliceValues converts the values to log.Value, and returns nil if the values are nil.
+func sliceValues[T any](values []T, convert func(T) log.Value) []log.Value {
+	if values == nil {
+		return nil
+	}
+	converted := make([]log.Value, len(values))
+	for i, v := range values {
+		converted[i] = convert(v)
+	}
+	return converted
+}
+
+// anyValue converts the value to log.Value.
+// The values of the types that log.Value has no kind for are converted to strings in the same way as fmt.Sprintf("%+v").
+func anyValue(value interface{}) log.Value { //nolint:cyclop
+	switch v := value.(type) {
+	case nil:
+		return log.Value{}
+	case log.Value:
+		return v
+	case string:
+		return log.StringValue(v)
+	case bool:
+		return log.BoolValue(v)
+	case int:
+		return log.IntValue(v)
+	case int8:
+		return log.Int64Value(int64(v))
+	case int16:
+		return log.Int64Value(int64(v))
+	case int32:
+		return log.Int64Value(int64(v))
+	case int64:
+		return log.Int64Value(v)
+	case uint:
+		return uint64Value(uint64(v))
+	case uint8:
+		return log.Int64Value(int64(v))
+	case uint16:
+		return log.Int64Value(int64(v))
+	case uint32:
+		return log.Int64Value(int64(v))
+	case uint64:
+		return uint64Value(v)
+	case float32:
+		return log.Float64Value(float64(v))
+	case float64:
+		return log.Float64Value(v)
+	case []byte:
+		return log.StringValue(string(v))
+	case time.Duration:
+		return durationValue(v)
+	case time.Time:
+		return timeValue(v)
+	case error:
+		return errorValue(v)
+	case ilog.ObjectMarshaler:
+		enc := &objectEncoder{}
+		_ = v.MarshalLogObject(enc)
+		return log.MapValue(enc.attrs...)
+	case ilog.ArrayMarshaler:
+		enc := &arrayEncoder{}
+		_ = v.MarshalLogArray(enc)
+		return log.SliceValue(enc.values...)
+	case fmt.Stringer:
+		return log.StringValue(v.String())
+	default:
+		return log.StringValue(fmt.Sprintf("%+v", v))
+	}
+}
+
+// uint64Value returns the value as int64 if it fits in int64, otherwise as a decimal string, since log.Value has no kind for uint64.
+func uint64Value(value uint64) log.Value {
+	if value > math.MaxInt64 {
+		return log.StringValue(strconv.FormatUint(value, 10))
+	}
+	return log.Int64Value(int64(value))
+}
+
+// durationValue returns the duration as a string such as "1h1m1s" in the same way as the default implementation of ilog.
+func durationValue(value time.Duration) log.Value {
+	return log.StringValue(value.String())
+}
+
+// timeValue returns the time as a RFC 3339 string in the same way as the default implementation of ilog.
+func timeValue(value time.Time) log.Value {
+	return log.StringValue(value.Format(time.RFC3339Nano))
+}
+
+// errorValue returns the message of the error, or the empty value if err is nil.
+func errorValue(err error) log.Value {
+	if err == nil {
+		return log.Value{}
+	}
+	return log.StringValue(err.Error())
+}
+
+// stacktrace returns the stack trace of the current goroutine in the same format as the default implementation of ilog.
+func stacktrace(skip int) string {
+	const depth = 64
+	pc := make([]uintptr, depth)
+	n := runtime.Callers(skip, pc)
+	for n == len(pc) {
+		pc = make([]uintptr, len(pc)*2) //nolint:gomnd
+		n = runtime.Callers(skip, pc)
+	}
+	if n == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := frames.Next()
+		b.WriteString(frame.Function)
+		b.WriteString("\n\t")
+		b.WriteString(frame.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(frame.Line))
+		if !more {
+			break
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// joinName returns the name of a child logger, e.g. "db.pool" for "db" and "pool".
+func joinName(parent, name string) string {
+	switch {
+	case parent == "":
+		return name
+	case name == "":
+		return parent
+	default:
+		return parent + "." + name
+	}
+}
+
+// lookupLevelVar returns the LevelVar configured for the name in the registry, or current if it is not configured.
+func lookupLevelVar(r *ilog.LevelRegistry, name string, current *ilog.LevelVar) *ilog.LevelVar {
+	if r == nil {
+		return current
+	}
+	if v := r.LevelVar(name); v != nil {
+		return v
+	}
+	return current
+}
+
+// sprintf returns format as it is if args is empty, otherwise fmt.Sprintf(format, args...).
+func sprintf(format string, args ...interface{}) string {
+	if len(args) > 0 {
+		return fmt.Sprintf(format, args...)
+	}
+	return format
+}
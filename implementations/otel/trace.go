@@ -0,0 +1,145 @@
+// Package otel provides the OpenTelemetry integrations of ilog.Logger.
+package otel
+
+import (
+	"context"
+	"encoding/binary"
+	"strconv"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/kunitsucom/ilog.go"
+)
+
+const (
+	// gcpTraceKey, gcpSpanIDKey and gcpTraceSampledKey are the keys of the special fields of Cloud Logging.
+	//
+	// cf. https://cloud.google.com/logging/docs/structured-logging#special-payload-fields
+	gcpTraceKey        = "logging.googleapis.com/trace"
+	gcpSpanIDKey       = "logging.googleapis.com/spanId"
+	gcpTraceSampledKey = "logging.googleapis.com/trace_sampled"
+
+	// datadogTraceIDKey and datadogSpanIDKey are the keys of the fields that Datadog uses to correlate logs and traces.
+	//
+	// cf. https://docs.datadoghq.com/tracing/other_telemetry/connect_logs_and_traces/opentelemetry/
+	datadogTraceIDKey = "dd.trace_id"
+	datadogSpanIDKey  = "dd.span_id"
+)
+
+// TraceOption is the option of NewTraceExtractor and FromContext.
+type TraceOption func(c *traceConfig)
+
+type traceConfig struct {
+	traceIDKey    string
+	spanIDKey     string
+	traceFlagsKey string
+	traceID       func(id trace.TraceID) string
+	spanID        func(id trace.SpanID) string
+	traceFlags    func(f fielder, key string, flags trace.TraceFlags) fielder
+}
+
+// fielder is the common interface of ilog.Logger and ilog.LogEntry that is used to add the fields.
+type fielder interface {
+	Bool(key string, value bool) ilog.LogEntry
+	String(key, value string) ilog.LogEntry
+}
+
+func newTraceConfig(opts ...TraceOption) *traceConfig {
+	c := &traceConfig{
+		traceIDKey:    "trace_id",
+		spanIDKey:     "span_id",
+		traceFlagsKey: "trace_flags",
+		traceID:       trace.TraceID.String,
+		spanID:        trace.SpanID.String,
+		traceFlags: func(f fielder, key string, flags trace.TraceFlags) fielder {
+			return f.String(key, flags.String())
+		},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithTraceKeys sets the keys of the trace ID, span ID and trace flags fields of the OpenTelemetry convention.
+// If a key is empty, the field is not added.
+// Default is "trace_id", "span_id" and "trace_flags", whose values are hex strings such as "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7" and "01".
+func WithTraceKeys(traceIDKey, spanIDKey, traceFlagsKey string) TraceOption {
+	return func(c *traceConfig) {
+		c.traceIDKey, c.spanIDKey, c.traceFlagsKey = traceIDKey, spanIDKey, traceFlagsKey
+	}
+}
+
+// WithGCP sets the fields to the convention of Cloud Logging, which are "logging.googleapis.com/trace" such as "projects/PROJECT_ID/traces/TRACE_ID",
+// "logging.googleapis.com/spanId" and "logging.googleapis.com/trace_sampled".
+func WithGCP(projectID string) TraceOption {
+	return func(c *traceConfig) {
+		c.traceIDKey, c.spanIDKey, c.traceFlagsKey = gcpTraceKey, gcpSpanIDKey, gcpTraceSampledKey
+		c.traceID = func(id trace.TraceID) string {
+			return "projects/" + projectID + "/traces/" + id.String()
+		}
+		c.spanID = trace.SpanID.String
+		c.traceFlags = func(f fielder, key string, flags trace.TraceFlags) fielder {
+			return f.Bool(key, flags.IsSampled())
+		}
+	}
+}
+
+// WithDatadog sets the fields to the convention of Datadog, which are "dd.trace_id" and "dd.span_id" as decimal strings.
+// The trace ID is the lower 64 bits of the OpenTelemetry trace ID, and the trace flags are not added.
+func WithDatadog() TraceOption {
+	return func(c *traceConfig) {
+		c.traceIDKey, c.spanIDKey, c.traceFlagsKey = datadogTraceIDKey, datadogSpanIDKey, ""
+		c.traceID = func(id trace.TraceID) string {
+			const base = 10
+			return strconv.FormatUint(binary.BigEndian.Uint64(id[8:]), base)
+		}
+		c.spanID = func(id trace.SpanID) string {
+			const base = 10
+			return strconv.FormatUint(binary.BigEndian.Uint64(id[:]), base)
+		}
+	}
+}
+
+// addFields adds the trace correlation fields of sc to f.
+func (c *traceConfig) addFields(f fielder, sc trace.SpanContext) fielder {
+	if len(c.traceIDKey) > 0 {
+		f = f.String(c.traceIDKey, c.traceID(sc.TraceID()))
+	}
+	if len(c.spanIDKey) > 0 {
+		f = f.String(c.spanIDKey, c.spanID(sc.SpanID()))
+	}
+	if len(c.traceFlagsKey) > 0 {
+		f = c.traceFlags(f, c.traceFlagsKey, sc.TraceFlags())
+	}
+	return f
+}
+
+// NewTraceExtractor returns an ilog.ContextExtractor that adds the trace correlation fields of the span in the context.
+// Register it by ilog.RegisterContextExtractor so that Ctx of all the implementations adds the fields.
+//
+//	ilog.RegisterContextExtractor(ilogotel.NewTraceExtractor(ilogotel.WithGCP("my-project")))
+func NewTraceExtractor(opts ...TraceOption) ilog.ContextExtractor {
+	c := newTraceConfig(opts...)
+	return func(ctx context.Context, entry ilog.LogEntry) ilog.LogEntry {
+		sc := trace.SpanContextFromContext(ctx)
+		if !sc.IsValid() {
+			return entry
+		}
+		return c.addFields(entry, sc).(ilog.LogEntry) //nolint:forcetypeassert
+	}
+}
+
+// FromContext returns the logger returned by ilog.FromContext with the trace correlation fields of the span in ctx.
+// If ctx has no valid span, the logger is returned as it is.
+func FromContext(ctx context.Context, opts ...TraceOption) ilog.Logger { //nolint:ireturn
+	l := ilog.FromContext(ctx)
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return l
+	}
+	if entry, ok := newTraceConfig(opts...).addFields(l, sc).(ilog.LogEntry); ok {
+		return entry.Logger()
+	}
+	return l
+}
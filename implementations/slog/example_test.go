@@ -0,0 +1,290 @@
+package slog_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/kunitsucom/ilog.go"
+	ilogslog "github.com/kunitsucom/ilog.go/implementations/slog"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	l := ilogslog.New(ilog.DebugLevel, slog.NewJSONHandler(buf, &slog.HandlerOptions{AddSource: true, Level: slog.LevelDebug})).
+		Any("any", "any").
+		Bool("bool", true).
+		Bytes("bytes", []byte("bytes")).
+		Duration("duration", time.Hour+time.Minute+time.Second+time.Millisecond+time.Microsecond+time.Nanosecond).
+		Err(io.ErrUnexpectedEOF).
+		ErrWithKey("err", io.ErrUnexpectedEOF).
+		Float32("float32", 1.1).
+		Float64("float64", 1.1).
+		Int("int", 1).
+		Int32("int32", 1).
+		Int64("int64", 1).
+		String("string", "string").
+		Time("time", time.Date(2023, 8, 13, 4, 38, 39, 123456789, time.FixedZone("Asia/Tokyo", int(9*time.Hour/time.Second)))).
+		Uint("uint", 1).
+		Uint32("uint32", 1).
+		Uint64("uint64", 1).
+		Object("object", ilog.ObjectMarshalerFunc(func(enc ilog.ObjectEncoder) error {
+			enc.AddString("string", "string")
+			enc.AddObject("nested", ilog.ObjectMarshalerFunc(func(enc ilog.ObjectEncoder) error {
+				enc.AddInt("int", 1)
+				return nil
+			}))
+			return nil
+		})).
+		Array("array", ilog.ArrayMarshalerFunc(func(enc ilog.ArrayEncoder) error {
+			enc.AppendString("string")
+			enc.AppendInt(1)
+			return nil
+		})).
+		Bools("bools", []bool{true}).
+		Durations("durations", []time.Duration{time.Second}).
+		Errs("errs", []error{io.ErrUnexpectedEOF}).
+		Float64s("float64s", []float64{1.1}).
+		Ints("ints", []int{1}).
+		Int64s("int64s", []int64{1}).
+		Strings("strings", []string{"string"}).
+		Times("times", []time.Time{time.Date(2023, 8, 13, 4, 38, 39, 123456789, time.UTC)}).
+		Logger()
+
+	l = l.String("append", "logger").Logger()
+
+	l = l.Group("group").String("string", "group").Logger()
+
+	l.String("string", "new logger").Debugf("debug message")
+
+	expected := regexp.MustCompilePOSIX(`{"time":"[^"]+","level":"DEBUG","source":{"function":"[^"]+","file":"[^"]+/example_test\.go","line":[0-9]+},"msg":"debug message","any":"any","bool":true,"bytes":"bytes","duration":3661001001001,"error":"unexpected EOF","err":"unexpected EOF","float32":1\.100000023841858,"float64":1\.1,"int":1,"int32":1,"int64":1,"string":"string","time":"2023-08-13T04:38:39\.123456789\+09:00","uint":1,"uint32":1,"uint64":1,"object":{"string":"string","nested":{"int":1}},"array":\["string",1\],"bools":\[true\],"durations":\[1000000000\],"errs":\["unexpected EOF"\],"float64s":\[1\.1\],"ints":\[1\],"int64s":\[1\],"strings":\["string"\],"times":\["2023-08-13T04:38:39\.123456789Z"\],"append":"logger","group":{"string":"group","string":"new logger"}}`)
+	if !expected.Match(buf.Bytes()) {
+		t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+	}
+
+	t.Logf("ℹ️: buf:\n%s", buf)
+}
+
+func TestLevel(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	l := ilogslog.New(ilog.InfoLevel, slog.NewTextHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	l.Debugf("debug message")
+	l.Infof("info message")
+	l.Warnf("warn message")
+	l.Errorf("error %s", "message")
+	l.Logf(ilog.ErrorLevel+2, "error+1 message")
+
+	expected := regexp.MustCompilePOSIX(`^time=[^ ]+ level=INFO msg="info message"
+time=[^ ]+ level=WARN msg="warn message"
+time=[^ ]+ level=ERROR msg="error message"
+time=[^ ]+ level=ERROR\+1 msg="error\+1 message"
+$`)
+	if !expected.Match(buf.Bytes()) {
+		t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+	}
+
+	t.Logf("ℹ️: buf:\n%s", buf)
+}
+
+func TestFromLogger(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	l := ilogslog.New(ilog.DebugLevel, slog.NewTextHandler(buf, nil)).String("key", "value").Logger()
+	ilogslog.FromLogger(l).Info("info message")
+
+	expected := regexp.MustCompilePOSIX(`^time=[^ ]+ level=INFO msg="info message" key=value
+$`)
+	if !expected.Match(buf.Bytes()) {
+		t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+	}
+
+	if ilogslog.FromLogger(ilog.NewBuilder(ilog.DebugLevel, io.Discard).Build()) != slog.Default() {
+		t.Errorf("❌: FromLogger: expected slog.Default()")
+	}
+}
+
+func TestStack(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	l := ilogslog.New(ilog.DebugLevel, slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}}))
+	l.Stack("stack").Debugf("Logger")
+	l.String("key", "value").Stack("stack").Debugf("LogEntry")
+
+	expected := regexp.MustCompilePOSIX(`^{"level":"DEBUG","msg":"Logger","stack":"github\.com/kunitsucom/ilog\.go/implementations/slog_test\.TestStack\\n\\t[^"]+/example_test\.go:[0-9]+\\n[^"]+"}
+{"level":"DEBUG","msg":"LogEntry","key":"value","stack":"github\.com/kunitsucom/ilog\.go/implementations/slog_test\.TestStack\\n\\t[^"]+/example_test\.go:[0-9]+\\n[^"]+"}
+$`)
+	if !expected.Match(buf.Bytes()) {
+		t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+	}
+
+	t.Logf("ℹ️: buf:\n%s", buf)
+}
+
+// testNilObject panics in MarshalLogObject if it is a typed nil.
+type testNilObject struct{ name string }
+
+func (o *testNilObject) MarshalLogObject(enc ilog.ObjectEncoder) error {
+	enc.AddString("name", o.name)
+	return nil
+}
+
+// NOTE: a nil marshaler is written as null like the default implementation, instead of panicking.
+func TestObject_nil(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	l := ilogslog.New(ilog.DebugLevel, slog.NewJSONHandler(buf, &slog.HandlerOptions{ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}}))
+	l.Object("object", nil).
+		Array("array", nil).
+		Object("typed", (*testNilObject)(nil)).
+		Object("nested", ilog.ObjectMarshalerFunc(func(enc ilog.ObjectEncoder) error {
+			enc.AddObject("object", nil)
+			enc.AddArray("array", nil)
+			return nil
+		})).
+		Array("elements", ilog.ArrayMarshalerFunc(func(enc ilog.ArrayEncoder) error {
+			enc.AppendObject(nil)
+			enc.AppendArray(nil)
+			return nil
+		})).
+		Infof("Infof")
+
+	const expected = `{"level":"INFO","msg":"Infof","object":null,"array":null,"typed":null,"nested":{"object":null,"array":null},"elements":[null,null]}` + "\n"
+	if actual := buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+func TestSetLevelVar(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	v := ilog.NewLevelVar(ilog.InfoLevel)
+	l := ilogslog.SetLevelVar(ilogslog.New(ilog.ErrorLevel, slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}})), v)
+	copied := l.Copy()
+
+	l.Debugf("hidden")
+	v.Set(ilog.DebugLevel)
+	copied.Debugf("Debugf")
+	l.SetLevel(ilog.WarnLevel).Infof("hidden")
+	l.Warnf("Warnf")
+
+	// NOTE: SetLevel does not change the LevelVar shared by the other loggers.
+	if expected, actual := ilog.DebugLevel, copied.Level(); expected != actual {
+		t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+	}
+	if expected, actual := `{"level":"DEBUG","msg":"Debugf"}`+"\n"+`{"level":"WARN","msg":"Warnf"}`+"\n", buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+func TestSetLevelRegistry(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	r := ilog.NewLevelRegistry()
+	r.Set("db.*", ilog.DebugLevel)
+	l := ilogslog.SetLevelRegistry(ilogslog.New(ilog.InfoLevel, slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}})), r)
+
+	l.Named("db").Debugf("hidden")
+	l.Named("db").Named("pool").String("key", "value").Debugf("Debugf")
+
+	if expected, actual := `{"level":"DEBUG","msg":"Debugf","logger":"db.pool","key":"value"}`+"\n", buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+//nolint:paralleltest
+func TestFatalf(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	var codes []int
+	defer ilog.SetExitFunc(func(code int) { codes = append(codes, code) })()
+	l := ilogslog.New(ilog.TraceLevel, slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.Level(-8), ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}}))
+
+	l.Tracef("Tracef")
+	l.String("key", "value").Fatalf("Fatalf: %s", "arg")
+	l.Logf(ilog.FatalLevel, "Logf")
+	func() {
+		defer func() {
+			if expected, actual := "Panicf", recover(); expected != actual {
+				t.Errorf("❌: expected(%v) != actual(%v)", expected, actual)
+			}
+		}()
+		l.Panicf("Panicf")
+	}()
+
+	const expected = `{"level":"DEBUG-4","msg":"Tracef"}` + "\n" +
+		`{"level":"ERROR+8","msg":"Fatalf: arg","key":"value"}` + "\n" +
+		`{"level":"ERROR+8","msg":"Logf"}` + "\n" +
+		`{"level":"ERROR+4","msg":"Panicf"}` + "\n"
+	if actual := buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+	if expected, actual := "[1]", fmt.Sprint(codes); expected != actual {
+		t.Errorf("❌: expected(%s) != actual(%s)", expected, actual)
+	}
+}
+
+type testContextKey struct{}
+
+// testContextHandler is the slog.Handler that adds the value of testContextKey in the context passed to Handle.
+type testContextHandler struct {
+	slog.Handler
+}
+
+func (h testContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if v, ok := ctx.Value(testContextKey{}).(string); ok {
+		r.AddAttrs(slog.String("handler", v))
+	}
+	return h.Handler.Handle(ctx, r) //nolint:wrapcheck
+}
+
+//nolint:paralleltest
+func TestCtx(t *testing.T) {
+	defer ilog.RegisterContextExtractor(ilog.ContextValue("request_id", testContextKey{}))()
+
+	buf := bytes.NewBuffer(nil)
+	l := ilogslog.New(ilog.DebugLevel, testContextHandler{slog.NewJSONHandler(buf, &slog.HandlerOptions{ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
+		if a.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return a
+	}})})
+	ctx := context.WithValue(context.Background(), testContextKey{}, "req-1")
+
+	l.Ctx(ctx).Infof("Infof")
+	l.String("key", "value").Ctx(ctx).Infof("Infof")
+
+	if expected, actual := `{"level":"INFO","msg":"Infof","request_id":"req-1","handler":"req-1"}`+"\n"+`{"level":"INFO","msg":"Infof","key":"value","request_id":"req-1","handler":"req-1"}`+"\n", buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
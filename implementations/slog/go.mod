@@ -0,0 +1,5 @@
+module github.com/kunitsucom/ilog.go/implementations/slog
+
+go 1.21.0
+
+require github.com/kunitsucom/ilog.go v0.0.2-rc.6
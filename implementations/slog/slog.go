@@ -0,0 +1,914 @@
+package slog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kunitsucom/ilog.go"
+)
+
+func FromLogger(ilogSlog ilog.Logger) *slog.Logger {
+	il, ok := ilogSlog.(*implLogger)
+	if !ok {
+		return slog.Default()
+	}
+	return slog.New(il.handler)
+}
+
+type implLogger struct {
+	level         ilog.Level
+	levelVar      *ilog.LevelVar
+	levelRegistry *ilog.LevelRegistry
+	name          string
+	handler       slog.Handler
+	callerSkip    int
+}
+
+func New(level ilog.Level, h slog.Handler) ilog.Logger { //nolint:ireturn
+	const skip = 3
+	return &implLogger{
+		level:      level,
+		handler:    h,
+		callerSkip: skip,
+	}
+}
+
+// SetLevelVar returns a copy of the logger that reads its level from the LevelVar instead of the level passed to New.
+// The LevelVar is shared by the copies of the logger, so changing it changes the level of all of them at runtime.
+func SetLevelVar(ilogSlog ilog.Logger, v *ilog.LevelVar) ilog.Logger { //nolint:ireturn
+	il, ok := ilogSlog.(*implLogger)
+	if !ok {
+		return ilogSlog
+	}
+	copied := il.copy()
+	copied.levelVar = v
+	return copied
+}
+
+func (l *implLogger) Level() ilog.Level {
+	if l.levelVar != nil {
+		return l.levelVar.Level()
+	}
+	return l.level
+}
+
+// SetLevel returns a copy of the logger with the level.
+// If the logger has a LevelVar set by SetLevelVar, the copy no longer reads it, so the level of the other loggers sharing it is not changed. To change all of them, use LevelVar.Set.
+func (l *implLogger) SetLevel(level ilog.Level) ilog.Logger { //nolint:ireturn
+	copied := l.copy()
+	copied.levelVar = nil
+	copied.level = level
+	return copied
+}
+
+func (l *implLogger) AddCallerSkip(skip int) ilog.Logger { //nolint:ireturn
+	copied := l.copy()
+	copied.callerSkip += skip
+	return copied
+}
+
+func (l *implLogger) Copy() ilog.Logger { //nolint:ireturn
+	return l.copy()
+}
+
+// SetLevelRegistry returns a copy of the logger whose descendants named by Named use the levels configured in the LevelRegistry.
+func SetLevelRegistry(ilogSlog ilog.Logger, r *ilog.LevelRegistry) ilog.Logger { //nolint:ireturn
+	il, ok := ilogSlog.(*implLogger)
+	if !ok {
+		return ilogSlog
+	}
+	copied := il.copy()
+	copied.levelRegistry = r
+	return copied
+}
+
+func (l *implLogger) Named(name string) ilog.Logger { //nolint:ireturn
+	copied := l.copy()
+	copied.name = joinName(l.name, name)
+	copied.levelVar = lookupLevelVar(copied.levelRegistry, copied.name, copied.levelVar)
+	return copied
+}
+
+func (l *implLogger) copy() *implLogger {
+	copied := *l
+	return &copied
+}
+
+func (l *implLogger) Any(key string, value interface{}) ilog.LogEntry { //nolint:ireturn
+	return l.new().Any(key, value)
+}
+
+func (l *implLogger) Bool(key string, value bool) ilog.LogEntry { //nolint:ireturn
+	return l.new().Bool(key, value)
+}
+
+func (l *implLogger) Bytes(key string, value []byte) ilog.LogEntry { //nolint:ireturn
+	return l.new().Bytes(key, value)
+}
+
+func (l *implLogger) Duration(key string, value time.Duration) ilog.LogEntry { //nolint:ireturn
+	return l.new().Duration(key, value)
+}
+
+func (l *implLogger) Err(err error) ilog.LogEntry { //nolint:ireturn
+	return l.new().Err(err)
+}
+
+func (l *implLogger) ErrWithKey(key string, err error) ilog.LogEntry { //nolint:ireturn
+	return l.new().ErrWithKey(key, err)
+}
+
+func (l *implLogger) Float32(key string, value float32) ilog.LogEntry { //nolint:ireturn
+	return l.new().Float32(key, value)
+}
+
+func (l *implLogger) Float64(key string, value float64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Float64(key, value)
+}
+
+func (l *implLogger) Int(key string, value int) ilog.LogEntry { //nolint:ireturn
+	return l.new().Int(key, value)
+}
+
+func (l *implLogger) Int32(key string, value int32) ilog.LogEntry { //nolint:ireturn
+	return l.new().Int32(key, value)
+}
+
+func (l *implLogger) Int64(key string, value int64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Int64(key, value)
+}
+
+func (l *implLogger) String(key, value string) ilog.LogEntry { //nolint:ireturn
+	return l.new().String(key, value)
+}
+
+func (l *implLogger) Time(key string, value time.Time) ilog.LogEntry { //nolint:ireturn
+	return l.new().Time(key, value)
+}
+
+func (l *implLogger) Uint(key string, value uint) ilog.LogEntry { //nolint:ireturn
+	return l.new().Uint(key, value)
+}
+
+func (l *implLogger) Uint32(key string, value uint32) ilog.LogEntry { //nolint:ireturn
+	return l.new().Uint32(key, value)
+}
+
+func (l *implLogger) Uint64(key string, value uint64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Uint64(key, value)
+}
+
+func (l *implLogger) Object(key string, marshaler ilog.ObjectMarshaler) ilog.LogEntry { //nolint:ireturn
+	return l.new().Object(key, marshaler)
+}
+
+func (l *implLogger) Group(name string) ilog.LogEntry { //nolint:ireturn
+	return l.new().Group(name)
+}
+
+func (l *implLogger) Array(key string, marshaler ilog.ArrayMarshaler) ilog.LogEntry { //nolint:ireturn
+	return l.new().Array(key, marshaler)
+}
+
+func (l *implLogger) Bools(key string, values []bool) ilog.LogEntry { //nolint:ireturn
+	return l.new().Bools(key, values)
+}
+
+func (l *implLogger) Durations(key string, values []time.Duration) ilog.LogEntry { //nolint:ireturn
+	return l.new().Durations(key, values)
+}
+
+func (l *implLogger) Errs(key string, errs []error) ilog.LogEntry { //nolint:ireturn
+	return l.new().Errs(key, errs)
+}
+
+func (l *implLogger) Float64s(key string, values []float64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Float64s(key, values)
+}
+
+func (l *implLogger) Ints(key string, values []int) ilog.LogEntry { //nolint:ireturn
+	return l.new().Ints(key, values)
+}
+
+func (l *implLogger) Int64s(key string, values []int64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Int64s(key, values)
+}
+
+func (l *implLogger) Strings(key string, values []string) ilog.LogEntry { //nolint:ireturn
+	return l.new().Strings(key, values)
+}
+
+func (l *implLogger) Times(key string, values []time.Time) ilog.LogEntry { //nolint:ireturn
+	return l.new().Times(key, values)
+}
+
+func (l *implLogger) Stack(key string) ilog.LogEntry { //nolint:ireturn
+	// NOTE: skip runtime.Callers, stacktrace and implLogger.Stack
+	const skip = 3
+	e := l.new()
+	e.add(slog.String(key, stacktrace(skip)))
+	return e
+}
+
+func (l *implLogger) Ctx(ctx context.Context) ilog.LogEntry { //nolint:ireturn
+	return l.new().Ctx(ctx)
+}
+
+func (l *implLogger) Tracef(format string, args ...interface{}) {
+	_ = l.new().logf(ilog.TraceLevel, format, args...)
+}
+
+func (l *implLogger) Debugf(format string, args ...interface{}) {
+	_ = l.new().logf(ilog.DebugLevel, format, args...)
+}
+
+func (l *implLogger) Infof(format string, args ...interface{}) {
+	_ = l.new().logf(ilog.InfoLevel, format, args...)
+}
+
+func (l *implLogger) Warnf(format string, args ...interface{}) {
+	_ = l.new().logf(ilog.WarnLevel, format, args...)
+}
+
+func (l *implLogger) Errorf(format string, args ...interface{}) {
+	_ = l.new().logf(ilog.ErrorLevel, format, args...)
+}
+
+func (l *implLogger) Panicf(format string, args ...interface{}) {
+	_ = l.new().logf(ilog.PanicLevel, format, args...)
+	panic(sprintf(format, args...))
+}
+
+func (l *implLogger) Fatalf(format string, args ...interface{}) {
+	_ = l.new().logf(ilog.FatalLevel, format, args...)
+	ilog.Exit(1)
+}
+
+func (l *implLogger) Logf(level ilog.Level, format string, args ...interface{}) {
+	_ = l.new().logf(level, format, args...)
+}
+
+func (l *implLogger) Write(p []byte) (int, error) {
+	if err := l.new().logf(l.Level(), string(p)); err != nil {
+		return 0, fmt.Errorf("l.logf: %w", err)
+	}
+	return len(p), nil
+}
+
+func (l *implLogger) new() *implLogEntry {
+	return &implLogEntry{
+		logger: l,
+		attrs:  make([]slog.Attr, 0),
+	}
+}
+
+//nolint:errname
+type implLogEntry struct {
+	logger *implLogger
+	// ctx is the context passed to slog.Handler, which is set by Ctx.
+	ctx    context.Context //nolint:containedctx
+	attrs  []slog.Attr
+	groups []group
+}
+
+// group is a group opened by Group.
+type group struct {
+	name  string
+	attrs []slog.Attr
+}
+
+func (*implLogEntry) Error() string {
+	return ilog.ErrLogEntryIsNotWritten.Error()
+}
+
+// add adds the attribute to the innermost open group, or to the log entry if no group is open.
+func (e *implLogEntry) add(attr slog.Attr) {
+	if n := len(e.groups); n > 0 {
+		e.groups[n-1].attrs = append(e.groups[n-1].attrs, attr)
+		return
+	}
+	e.attrs = append(e.attrs, attr)
+}
+
+func (e *implLogEntry) Any(key string, value interface{}) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Any(key, value))
+	return e
+}
+
+func (e *implLogEntry) Bool(key string, value bool) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Bool(key, value))
+	return e
+}
+
+func (e *implLogEntry) Bytes(key string, value []byte) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.String(key, string(value)))
+	return e
+}
+
+func (e *implLogEntry) Duration(key string, value time.Duration) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Duration(key, value))
+	return e
+}
+
+func (e *implLogEntry) Err(err error) ilog.LogEntry { //nolint:ireturn
+	return e.ErrWithKey("error", err)
+}
+
+func (e *implLogEntry) ErrWithKey(key string, err error) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Any(key, err))
+	return e
+}
+
+func (e *implLogEntry) Float32(key string, value float32) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Float64(key, float64(value)))
+	return e
+}
+
+func (e *implLogEntry) Float64(key string, value float64) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Float64(key, value))
+	return e
+}
+
+func (e *implLogEntry) Int(key string, value int) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Int(key, value))
+	return e
+}
+
+func (e *implLogEntry) Int32(key string, value int32) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Int64(key, int64(value)))
+	return e
+}
+
+func (e *implLogEntry) Int64(key string, value int64) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Int64(key, value))
+	return e
+}
+
+func (e *implLogEntry) String(key, value string) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.String(key, value))
+	return e
+}
+
+func (e *implLogEntry) Time(key string, value time.Time) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Time(key, value))
+	return e
+}
+
+func (e *implLogEntry) Uint(key string, value uint) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Uint64(key, uint64(value)))
+	return e
+}
+
+func (e *implLogEntry) Uint32(key string, value uint32) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Uint64(key, uint64(value)))
+	return e
+}
+
+func (e *implLogEntry) Uint64(key string, value uint64) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Uint64(key, value))
+	return e
+}
+
+// Object adds the nested object as a group attribute.
+// If the marshaler returns an error, the error is added with the key suffixed by "Error".
+// If the marshaler is nil or panics, nil is added as the default implementation adds null.
+func (e *implLogEntry) Object(key string, marshaler ilog.ObjectMarshaler) ilog.LogEntry { //nolint:ireturn
+	enc, err := marshalObject(marshaler)
+	if enc == nil {
+		e.add(slog.Any(key, nil))
+		return e
+	}
+	e.add(slog.Attr{Key: key, Value: slog.GroupValue(enc.attrs...)})
+	if err != nil {
+		e.add(slog.Any(key+"Error", err))
+	}
+	return e
+}
+
+func (e *implLogEntry) Group(name string) ilog.LogEntry { //nolint:ireturn
+	e.groups = append(e.groups, group{name: name})
+	return e
+}
+
+// Array adds the array as an attribute whose value is []interface{}, since slog has no kind for arrays.
+// If the marshaler returns an error, the error is added with the key suffixed by "Error".
+// If the marshaler is nil or panics, nil is added as the default implementation adds null.
+func (e *implLogEntry) Array(key string, marshaler ilog.ArrayMarshaler) ilog.LogEntry { //nolint:ireturn
+	enc, err := marshalArray(marshaler)
+	if enc == nil {
+		e.add(slog.Any(key, nil))
+		return e
+	}
+	e.add(slog.Any(key, enc.values))
+	if err != nil {
+		e.add(slog.Any(key+"Error", err))
+	}
+	return e
+}
+
+func (e *implLogEntry) Bools(key string, values []bool) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Any(key, values))
+	return e
+}
+
+func (e *implLogEntry) Durations(key string, values []time.Duration) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Any(key, values))
+	return e
+}
+
+func (e *implLogEntry) Errs(key string, errs []error) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Any(key, errorValues(errs)))
+	return e
+}
+
+func (e *implLogEntry) Float64s(key string, values []float64) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Any(key, values))
+	return e
+}
+
+func (e *implLogEntry) Ints(key string, values []int) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Any(key, values))
+	return e
+}
+
+func (e *implLogEntry) Int64s(key string, values []int64) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Any(key, values))
+	return e
+}
+
+func (e *implLogEntry) Strings(key string, values []string) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Any(key, values))
+	return e
+}
+
+func (e *implLogEntry) Times(key string, values []time.Time) ilog.LogEntry { //nolint:ireturn
+	e.add(slog.Any(key, values))
+	return e
+}
+
+func (e *implLogEntry) Stack(key string) ilog.LogEntry { //nolint:ireturn
+	// NOTE: skip runtime.Callers, stacktrace and implLogEntry.Stack
+	const skip = 3
+	e.add(slog.String(key, stacktrace(skip)))
+	return e
+}
+
+func (e *implLogEntry) Logger() ilog.Logger { //nolint:ireturn
+	copied := e.logger.copy()
+	copied.handler = copied.handler.WithAttrs(e.attrs)
+	for _, g := range e.groups {
+		copied.handler = copied.handler.WithGroup(g.name).WithAttrs(g.attrs)
+	}
+	return copied
+}
+
+func (e *implLogEntry) Write(p []byte) (int, error) {
+	if err := e.logf(e.logger.Level(), string(p)); err != nil {
+		return 0, fmt.Errorf("e.logf: %w", err)
+	}
+	return len(p), nil
+}
+
+// Ctx adds the fields extracted from ctx, and passes ctx to slog.Handler.
+func (e *implLogEntry) Ctx(ctx context.Context) ilog.LogEntry { //nolint:ireturn
+	if ctx != nil {
+		e.ctx = ctx
+	}
+	return ilog.ExtractContext(ctx, e)
+}
+
+func (e *implLogEntry) Tracef(format string, args ...interface{}) {
+	_ = e.logf(ilog.TraceLevel, format, args...)
+}
+
+func (e *implLogEntry) Debugf(format string, args ...interface{}) {
+	_ = e.logf(ilog.DebugLevel, format, args...)
+}
+
+func (e *implLogEntry) Infof(format string, args ...interface{}) {
+	_ = e.logf(ilog.InfoLevel, format, args...)
+}
+
+func (e *implLogEntry) Warnf(format string, args ...interface{}) {
+	_ = e.logf(ilog.WarnLevel, format, args...)
+}
+
+func (e *implLogEntry) Errorf(format string, args ...interface{}) {
+	_ = e.logf(ilog.ErrorLevel, format, args...)
+}
+
+func (e *implLogEntry) Panicf(format string, args ...interface{}) {
+	_ = e.logf(ilog.PanicLevel, format, args...)
+	panic(sprintf(format, args...))
+}
+
+func (e *implLogEntry) Fatalf(format string, args ...interface{}) {
+	_ = e.logf(ilog.FatalLevel, format, args...)
+	ilog.Exit(1)
+}
+
+func (e *implLogEntry) Logf(level ilog.Level, format string, args ...interface{}) {
+	_ = e.logf(level, format, args...)
+}
+
+func (e *implLogEntry) logf(level ilog.Level, format string, args ...interface{}) error {
+	if level < e.logger.Level() {
+		return nil
+	}
+
+	ctx := e.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	slogLevel := toSlogLevel(level)
+	if !e.logger.handler.Enabled(ctx, slogLevel) {
+		return nil
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(e.logger.callerSkip, pcs[:])
+
+	msg := format
+	if len(args) > 0 {
+		msg = fmt.Sprintf(format, args...)
+	}
+
+	r := slog.NewRecord(time.Now(), slogLevel, msg, pcs[0])
+	if len(e.logger.name) > 0 {
+		r.AddAttrs(slog.String(nameKey, e.logger.name))
+	}
+	r.AddAttrs(e.attrs...)
+	if len(e.groups) > 0 {
+		r.AddAttrs(e.groupAttr(0))
+	}
+
+	if err := e.logger.handler.Handle(ctx, r); err != nil {
+		return fmt.Errorf("e.logger.handler.Handle: %w", err)
+	}
+
+	return nil
+}
+
+// groupAttr returns the group attribute of groups[i] which contains the attributes of the deeper groups.
+func (e *implLogEntry) groupAttr(i int) slog.Attr {
+	attrs := e.groups[i].attrs
+	if i+1 < len(e.groups) {
+		attrs = append(attrs[:len(attrs):len(attrs)], e.groupAttr(i+1))
+	}
+	return slog.Attr{Key: e.groups[i].name, Value: slog.GroupValue(attrs...)}
+}
+
+// toSlogLevel converts ilog.Level to slog.Level.
+// The standard levels of ilog are twice as large as those of slog, so the other levels are scaled in the same way.
+func toSlogLevel(level ilog.Level) slog.Level {
+	switch level {
+	case ilog.DebugLevel:
+		return slog.LevelDebug
+	case ilog.InfoLevel:
+		return slog.LevelInfo
+	case ilog.WarnLevel:
+		return slog.LevelWarn
+	case ilog.ErrorLevel:
+		return slog.LevelError
+	default:
+		const scale = 2
+		return slog.Level(level / scale)
+	}
+}
+
+// objectEncoder is ilog.ObjectEncoder that collects the fields of a nested object as slog attributes.
+type objectEncoder struct {
+	attrs []slog.Attr
+}
+
+func (enc *objectEncoder) AddAny(key string, value interface{}) {
+	enc.attrs = append(enc.attrs, slog.Any(key, value))
+}
+
+func (enc *objectEncoder) AddBool(key string, value bool) {
+	enc.attrs = append(enc.attrs, slog.Bool(key, value))
+}
+
+func (enc *objectEncoder) AddBytes(key string, value []byte) {
+	enc.attrs = append(enc.attrs, slog.String(key, string(value)))
+}
+
+func (enc *objectEncoder) AddDuration(key string, value time.Duration) {
+	enc.attrs = append(enc.attrs, slog.Duration(key, value))
+}
+
+func (enc *objectEncoder) AddErr(key string, err error) {
+	enc.attrs = append(enc.attrs, slog.Any(key, err))
+}
+
+func (enc *objectEncoder) AddFloat32(key string, value float32) {
+	enc.attrs = append(enc.attrs, slog.Float64(key, float64(value)))
+}
+
+func (enc *objectEncoder) AddFloat64(key string, value float64) {
+	enc.attrs = append(enc.attrs, slog.Float64(key, value))
+}
+
+func (enc *objectEncoder) AddInt(key string, value int) {
+	enc.attrs = append(enc.attrs, slog.Int(key, value))
+}
+
+func (enc *objectEncoder) AddInt32(key string, value int32) {
+	enc.attrs = append(enc.attrs, slog.Int64(key, int64(value)))
+}
+
+func (enc *objectEncoder) AddInt64(key string, value int64) {
+	enc.attrs = append(enc.attrs, slog.Int64(key, value))
+}
+
+func (enc *objectEncoder) AddObject(key string, marshaler ilog.ObjectMarshaler) {
+	nested, err := marshalObject(marshaler)
+	if nested == nil {
+		enc.attrs = append(enc.attrs, slog.Any(key, nil))
+		return
+	}
+	enc.attrs = append(enc.attrs, slog.Attr{Key: key, Value: slog.GroupValue(nested.attrs...)})
+	if err != nil {
+		enc.attrs = append(enc.attrs, slog.Any(key+"Error", err))
+	}
+}
+
+func (enc *objectEncoder) AddString(key, value string) {
+	enc.attrs = append(enc.attrs, slog.String(key, value))
+}
+
+func (enc *objectEncoder) AddTime(key string, value time.Time) {
+	enc.attrs = append(enc.attrs, slog.Time(key, value))
+}
+
+func (enc *objectEncoder) AddUint(key string, value uint) {
+	enc.attrs = append(enc.attrs, slog.Uint64(key, uint64(value)))
+}
+
+func (enc *objectEncoder) AddUint32(key string, value uint32) {
+	enc.attrs = append(enc.attrs, slog.Uint64(key, uint64(value)))
+}
+
+func (enc *objectEncoder) AddUint64(key string, value uint64) {
+	enc.attrs = append(enc.attrs, slog.Uint64(key, value))
+}
+
+func (enc *objectEncoder) AddArray(key string, marshaler ilog.ArrayMarshaler) {
+	nested, err := marshalArray(marshaler)
+	if nested == nil {
+		enc.attrs = append(enc.attrs, slog.Any(key, nil))
+		return
+	}
+	enc.attrs = append(enc.attrs, slog.Any(key, nested.values))
+	if err != nil {
+		enc.attrs = append(enc.attrs, slog.Any(key+"Error", err))
+	}
+}
+
+func (enc *objectEncoder) AddBools(key string, values []bool) {
+	enc.attrs = append(enc.attrs, slog.Any(key, values))
+}
+
+func (enc *objectEncoder) AddDurations(key string, values []time.Duration) {
+	enc.attrs = append(enc.attrs, slog.Any(key, values))
+}
+
+func (enc *objectEncoder) AddErrs(key string, errs []error) {
+	enc.attrs = append(enc.attrs, slog.Any(key, errorValues(errs)))
+}
+
+func (enc *objectEncoder) AddFloat64s(key string, values []float64) {
+	enc.attrs = append(enc.attrs, slog.Any(key, values))
+}
+
+func (enc *objectEncoder) AddInts(key string, values []int) {
+	enc.attrs = append(enc.attrs, slog.Any(key, values))
+}
+
+func (enc *objectEncoder) AddInt64s(key string, values []int64) {
+	enc.attrs = append(enc.attrs, slog.Any(key, values))
+}
+
+func (enc *objectEncoder) AddStrings(key string, values []string) {
+	enc.attrs = append(enc.attrs, slog.Any(key, values))
+}
+
+func (enc *objectEncoder) AddTimes(key string, values []time.Time) {
+	enc.attrs = append(enc.attrs, slog.Any(key, values))
+}
+
+// marshalObject returns the objectEncoder that the marshaler added the fields to, or nil if the marshaler is nil or panics.
+func marshalObject(marshaler ilog.ObjectMarshaler) (enc *objectEncoder, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			enc, err = nil, nil
+		}
+	}()
+	// NOTE: Even if marshaler is nil, it is not judged as nil because it may have type information. Calling marshaler.MarshalLogObject() causes panic.
+	enc = &objectEncoder{}
+	return enc, marshaler.MarshalLogObject(enc)
+}
+
+// marshalArray returns the arrayEncoder that the marshaler appended the elements to, or nil if the marshaler is nil or panics.
+func marshalArray(marshaler ilog.ArrayMarshaler) (enc *arrayEncoder, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			enc, err = nil, nil
+		}
+	}()
+	enc = &arrayEncoder{}
+	return enc, marshaler.MarshalLogArray(enc)
+}
+
+// arrayEncoder is ilog.ArrayEncoder that collects the elements of an array.
+// Nested objects are collected as map[string]interface{} so that slog handlers can encode them.
+type arrayEncoder struct {
+	values []interface{}
+}
+
+func (enc *arrayEncoder) AppendAny(value interface{}) {
+	enc.values = append(enc.values, value)
+}
+
+func (enc *arrayEncoder) AppendArray(marshaler ilog.ArrayMarshaler) {
+	nested, _ := marshalArray(marshaler)
+	if nested == nil {
+		enc.values = append(enc.values, nil)
+		return
+	}
+	enc.values = append(enc.values, nested.values)
+}
+
+func (enc *arrayEncoder) AppendBool(value bool) {
+	enc.values = append(enc.values, value)
+}
+
+func (enc *arrayEncoder) AppendBytes(value []byte) {
+	enc.values = append(enc.values, string(value))
+}
+
+func (enc *arrayEncoder) AppendDuration(value time.Duration) {
+	enc.values = append(enc.values, value)
+}
+
+func (enc *arrayEncoder) AppendErr(err error) {
+	enc.values = append(enc.values, errorValue(err))
+}
+
+func (enc *arrayEncoder) AppendFloat32(value float32) {
+	enc.values = append(enc.values, value)
+}
+
+func (enc *arrayEncoder) AppendFloat64(value float64) {
+	enc.values = append(enc.values, value)
+}
+
+func (enc *arrayEncoder) AppendInt(value int) {
+	enc.values = append(enc.values, value)
+}
+
+func (enc *arrayEncoder) AppendInt32(value int32) {
+	enc.values = append(enc.values, value)
+}
+
+func (enc *arrayEncoder) AppendInt64(value int64) {
+	enc.values = append(enc.values, value)
+}
+
+func (enc *arrayEncoder) AppendObject(marshaler ilog.ObjectMarshaler) {
+	nested, _ := marshalObject(marshaler)
+	if nested == nil {
+		enc.values = append(enc.values, nil)
+		return
+	}
+	enc.values = append(enc.values, attrsToMap(nested.attrs))
+}
+
+func (enc *arrayEncoder) AppendString(value string) {
+	enc.values = append(enc.values, value)
+}
+
+func (enc *arrayEncoder) AppendTime(value time.Time) {
+	enc.values = append(enc.values, value)
+}
+
+func (enc *arrayEncoder) AppendUint(value uint) {
+	enc.values = append(enc.values, value)
+}
+
+func (enc *arrayEncoder) AppendUint32(value uint32) {
+	enc.values = append(enc.values, value)
+}
+
+func (enc *arrayEncoder) AppendUint64(value uint64) {
+	enc.values = append(enc.values, value)
+}
+
+// errorValue returns the message of the error, since most errors are encoded as empty objects by encoding/json.
+func errorValue(err error) interface{} {
+	if err == nil {
+		return nil
+	}
+	return err.Error()
+}
+
+func errorValues(errs []error) []interface{} {
+	if errs == nil {
+		return nil
+	}
+	values := make([]interface{}, len(errs))
+	for i, err := range errs {
+		values[i] = errorValue(err)
+	}
+	return values
+}
+
+func attrsToMap(attrs []slog.Attr) map[string]interface{} {
+	m := make(map[string]interface{}, len(attrs))
+	for _, a := range attrs {
+		v := a.Value.Resolve()
+		switch {
+		case v.Kind() == slog.KindGroup:
+			m[a.Key] = attrsToMap(v.Group())
+		case v.Kind() == slog.KindAny:
+			if err, ok := v.Any().(error); ok {
+				m[a.Key] = err.Error()
+				continue
+			}
+			m[a.Key] = v.Any()
+		default:
+			m[a.Key] = v.Any()
+		}
+	}
+	return m
+}
+
+// stacktrace returns the stack trace of the current goroutine in the same format as the default implementation of ilog.
+func stacktrace(skip int) string {
+	const depth = 64
+	pc := make([]uintptr, depth)
+	n := runtime.Callers(skip, pc)
+	for n == len(pc) {
+		pc = make([]uintptr, len(pc)*2) //nolint:gomnd
+		n = runtime.Callers(skip, pc)
+	}
+	if n == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := frames.Next()
+		b.WriteString(frame.Function)
+		b.WriteString("\n\t")
+		b.WriteString(frame.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(frame.Line))
+		if !more {
+			break
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// joinName returns the name of a child logger, e.g. "db.pool" for "db" and "pool".
+func joinName(parent, name string) string {
+	switch {
+	case parent == "":
+		return name
+	case name == "":
+		return parent
+	default:
+		return parent + "." + name
+	}
+}
+
+// lookupLevelVar returns the LevelVar configured for the name in the registry, or current if it is not configured.
+func lookupLevelVar(r *ilog.LevelRegistry, name string, current *ilog.LevelVar) *ilog.LevelVar {
+	if r == nil {
+		return current
+	}
+	if v := r.LevelVar(name); v != nil {
+		return v
+	}
+	return current
+}
+
+// nameKey is the key of the logger name field set by Named.
+const nameKey = "logger"
+
+// sprintf returns format as it is if args is empty, otherwise fmt.Sprintf(format, args...).
+func sprintf(format string, args ...interface{}) string {
+	if len(args) > 0 {
+		return fmt.Sprintf(format, args...)
+	}
+	return format
+}
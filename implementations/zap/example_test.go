@@ -2,7 +2,10 @@ package zap_test
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
+	"regexp"
 	"testing"
 	"time"
 
@@ -33,11 +36,245 @@ func TestNew(t *testing.T) {
 		Uint("uint", 1).
 		Uint32("uint32", 1).
 		Uint64("uint64", 1).
+		Object("object", ilog.ObjectMarshalerFunc(func(enc ilog.ObjectEncoder) error {
+			enc.AddString("string", "string")
+			enc.AddObject("nested", ilog.ObjectMarshalerFunc(func(enc ilog.ObjectEncoder) error {
+				enc.AddInt("int", 1)
+				return nil
+			}))
+			return nil
+		})).
+		Array("array", ilog.ArrayMarshalerFunc(func(enc ilog.ArrayEncoder) error {
+			enc.AppendString("string")
+			enc.AppendInt(1)
+			return nil
+		})).
+		Bools("bools", []bool{true}).
+		Durations("durations", []time.Duration{time.Second}).
+		Errs("errs", []error{io.ErrUnexpectedEOF}).
+		Float64s("float64s", []float64{1.1}).
+		Ints("ints", []int{1}).
+		Int64s("int64s", []int64{1}).
+		Strings("strings", []string{"string"}).
+		Times("times", []time.Time{time.Date(2023, 8, 13, 4, 38, 39, 123456789, time.UTC)}).
 		Logger()
 
 	l = l.String("append", "logger").Logger()
 
+	l = l.Group("group").String("string", "group").Logger()
+
 	l.String("string", "new logger").Debugf("debug message")
 
 	t.Logf("ℹ️: buf:\n%s", buf)
 }
+
+func TestStack(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	l := ilogzap.New(ilog.DebugLevel, zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}), zapcore.AddSync(buf), zapcore.DebugLevel)))
+	l.Stack("stack").Debugf("Logger")
+	l.String("key", "value").Stack("stack").Debugf("LogEntry")
+
+	expected := regexp.MustCompilePOSIX(`^{"msg":"Logger","stack":"github\.com/kunitsucom/ilog\.go/implementations/zap_test\.TestStack\\n\\t[^"]+/example_test\.go:[0-9]+\\n[^"]+"}
+{"msg":"LogEntry","key":"value","stack":"github\.com/kunitsucom/ilog\.go/implementations/zap_test\.TestStack\\n\\t[^"]+/example_test\.go:[0-9]+\\n[^"]+"}
+$`)
+	if !expected.Match(buf.Bytes()) {
+		t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+	}
+
+	t.Logf("ℹ️: buf:\n%s", buf)
+}
+
+// testNilObject panics in MarshalLogObject if it is a typed nil.
+type testNilObject struct{ name string }
+
+func (o *testNilObject) MarshalLogObject(enc ilog.ObjectEncoder) error {
+	enc.AddString("name", o.name)
+	return nil
+}
+
+// NOTE: a nil marshaler is written as null like the default implementation, and the panic of a typed nil marshaler is written as an error, instead of panicking.
+func TestObject_nil(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	l := ilogzap.New(ilog.DebugLevel, zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}), zapcore.AddSync(buf), zapcore.DebugLevel)))
+	l.Object("object", nil).
+		Array("array", nil).
+		Object("typed", (*testNilObject)(nil)).
+		Object("nested", ilog.ObjectMarshalerFunc(func(enc ilog.ObjectEncoder) error {
+			enc.AddObject("object", nil)
+			enc.AddArray("array", nil)
+			return nil
+		})).
+		Array("elements", ilog.ArrayMarshalerFunc(func(enc ilog.ArrayEncoder) error {
+			enc.AppendObject(nil)
+			enc.AppendArray(nil)
+			return nil
+		})).
+		Infof("Infof")
+
+	const expected = `{"msg":"Infof","object":null,"array":null,"typed":{},"typedError":"ilog: MarshalLogObject panicked: runtime error: invalid memory address or nil pointer dereference","nested":{"object":null,"array":null},"elements":[null,null]}` + "\n"
+	if actual := buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+func TestAddHook(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	var entries []ilog.HookEntry
+	l := ilogzap.AddHook(
+		ilogzap.New(ilog.InfoLevel, zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}), zapcore.AddSync(buf), zapcore.DebugLevel))),
+		ilog.HookFunc(func(entry ilog.HookEntry) { entries = append(entries, entry) }),
+	)
+	l = l.String("logger", "logger").Logger()
+	l.Debugf("Debugf")
+	l.Int("int", 1).Group("group").String("string", "group").Errorf("Errorf: %s", "arg")
+
+	if expected, actual := 1, len(entries); expected != actual {
+		t.Fatalf("❌: expected(%d) != actual(%d)", expected, actual)
+	}
+	if expected, actual := ilog.ErrorLevel, entries[0].Level; expected != actual {
+		t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+	}
+	if expected, actual := "Errorf: arg", entries[0].Message; expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+	if expected, actual := "map[group:map[string:group] int:1 logger:logger]", fmt.Sprint(entries[0].Fields); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+
+	t.Logf("ℹ️: buf:\n%s", buf)
+}
+
+type testSyncer struct {
+	io.Writer
+	synced int
+}
+
+func (s *testSyncer) Sync() error {
+	s.synced++
+	return nil
+}
+
+func TestSync(t *testing.T) {
+	t.Parallel()
+	ws := &testSyncer{Writer: io.Discard}
+	l := ilogzap.New(ilog.DebugLevel, zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{}), ws, zapcore.DebugLevel)))
+
+	s, ok := l.(ilog.Syncer)
+	if !ok {
+		t.Fatalf("❌: %T does not implement ilog.Syncer", l)
+	}
+	if err := s.Sync(); err != nil {
+		t.Errorf("❌: err != nil: %v", err)
+	}
+	if expected, actual := 1, ws.synced; expected != actual {
+		t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+	}
+}
+
+func TestSetLevelVar(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	v := ilog.NewLevelVar(ilog.InfoLevel)
+	l := ilogzap.SetLevelVar(ilogzap.New(ilog.ErrorLevel, zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}), zapcore.AddSync(buf), zapcore.DebugLevel))), v)
+	copied := l.Copy()
+
+	l.Debugf("hidden")
+	v.Set(ilog.DebugLevel)
+	copied.Debugf("Debugf")
+	l.SetLevel(ilog.WarnLevel).Infof("hidden")
+	l.Warnf("Warnf")
+
+	// NOTE: SetLevel does not change the LevelVar shared by the other loggers.
+	if expected, actual := ilog.DebugLevel, copied.Level(); expected != actual {
+		t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+	}
+	if expected, actual := `{"msg":"Debugf"}`+"\n"+`{"msg":"Warnf"}`+"\n", buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+func TestSetLevelRegistry(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	r := ilog.NewLevelRegistry()
+	r.Set("db.*", ilog.DebugLevel)
+	l := ilogzap.SetLevelRegistry(ilogzap.New(ilog.InfoLevel, zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg", NameKey: "logger"}), zapcore.AddSync(buf), zapcore.DebugLevel))), r)
+
+	l.Named("db").Debugf("hidden")
+	l.Named("db").Named("pool").String("key", "value").Debugf("Debugf")
+
+	if expected, actual := `{"logger":"db.pool","msg":"Debugf","key":"value"}`+"\n", buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+//nolint:paralleltest
+func TestFatalf(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	var codes []int
+	defer ilog.SetExitFunc(func(code int) { codes = append(codes, code) })()
+	l := ilogzap.New(ilog.TraceLevel, zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: zapcore.CapitalLevelEncoder}), zapcore.AddSync(buf), zapcore.DebugLevel)))
+
+	l.Tracef("Tracef")
+	l.String("key", "value").Fatalf("Fatalf: %s", "arg")
+	l.Logf(ilog.FatalLevel, "Logf")
+	func() {
+		defer func() {
+			if expected, actual := "Panicf", recover(); expected != actual {
+				t.Errorf("❌: expected(%v) != actual(%v)", expected, actual)
+			}
+		}()
+		l.Panicf("Panicf")
+	}()
+
+	const expected = `{"level":"DEBUG","msg":"Tracef"}` + "\n" +
+		`{"level":"FATAL","msg":"Fatalf: arg","key":"value"}` + "\n" +
+		`{"level":"FATAL","msg":"Logf"}` + "\n" +
+		`{"level":"PANIC","msg":"Panicf"}` + "\n"
+	if actual := buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+	if expected, actual := "[1]", fmt.Sprint(codes); expected != actual {
+		t.Errorf("❌: expected(%s) != actual(%s)", expected, actual)
+	}
+}
+
+func TestLogf(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	l := ilogzap.New(-128, zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: zapcore.CapitalLevelEncoder}), zapcore.AddSync(buf), zapcore.DebugLevel)))
+
+	for _, level := range []ilog.Level{-128, 4, 12, 20, 40} {
+		l.Logf(level, "Logf: %d", level)
+	}
+
+	const expected = `{"level":"DEBUG","msg":"Logf: -128"}` + "\n" +
+		`{"level":"INFO","msg":"Logf: 4"}` + "\n" +
+		`{"level":"WARN","msg":"Logf: 12"}` + "\n" +
+		`{"level":"ERROR","msg":"Logf: 20"}` + "\n" +
+		`{"level":"FATAL","msg":"Logf: 40"}` + "\n"
+	if actual := buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+type testContextKey struct{}
+
+//nolint:paralleltest
+func TestCtx(t *testing.T) {
+	defer ilog.RegisterContextExtractor(ilog.ContextValue("request_id", testContextKey{}))()
+
+	buf := bytes.NewBuffer(nil)
+	l := ilogzap.New(ilog.DebugLevel, zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"}), zapcore.AddSync(buf), zapcore.DebugLevel)))
+	ctx := context.WithValue(context.Background(), testContextKey{}, "req-1")
+
+	l.Ctx(ctx).Infof("Infof")
+	l.String("key", "value").Ctx(ctx).Infof("Infof")
+
+	if expected, actual := `{"msg":"Infof","request_id":"req-1"}`+"\n"+`{"msg":"Infof","key":"value","request_id":"req-1"}`+"\n", buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
@@ -1,9 +1,12 @@
 package zap
 
 import (
+	"context"
+	"fmt"
 	"time"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/kunitsucom/ilog.go"
 )
@@ -17,8 +20,11 @@ func FromLogger(ilogZap ilog.Logger) *zap.Logger {
 }
 
 type implLogger struct {
-	level     ilog.Level
-	zapLogger *zap.Logger
+	level         ilog.Level
+	levelVar      *ilog.LevelVar
+	levelRegistry *ilog.LevelRegistry
+	name          string
+	zapLogger     *zap.Logger
 }
 
 func New(level ilog.Level, logger *zap.Logger) ilog.Logger { //nolint:ireturn
@@ -29,12 +35,44 @@ func New(level ilog.Level, logger *zap.Logger) ilog.Logger { //nolint:ireturn
 	}
 }
 
+// AddHook returns a copy of the logger that calls the hooks for every log entry written by the logger.
+// The hooks are called synchronously by zapcore.Core. The fields added to the logger before AddHook is called are not passed to the hooks.
+func AddHook(ilogZap ilog.Logger, hooks ...ilog.Hook) ilog.Logger { //nolint:ireturn
+	il, ok := ilogZap.(*implLogger)
+	if !ok {
+		return ilogZap
+	}
+	copied := il.copy()
+	copied.zapLogger = il.zapLogger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, &hookCore{LevelEnabler: core, hooks: hooks})
+	}))
+	return copied
+}
+
+// SetLevelVar returns a copy of the logger that reads its level from the LevelVar instead of the level passed to New.
+// The LevelVar is shared by the copies of the logger, so changing it changes the level of all of them at runtime.
+func SetLevelVar(ilogZap ilog.Logger, v *ilog.LevelVar) ilog.Logger { //nolint:ireturn
+	il, ok := ilogZap.(*implLogger)
+	if !ok {
+		return ilogZap
+	}
+	copied := il.copy()
+	copied.levelVar = v
+	return copied
+}
+
 func (l *implLogger) Level() ilog.Level {
+	if l.levelVar != nil {
+		return l.levelVar.Level()
+	}
 	return l.level
 }
 
+// SetLevel returns a copy of the logger with the level.
+// If the logger has a LevelVar set by SetLevelVar, the copy no longer reads it, so the level of the other loggers sharing it is not changed. To change all of them, use LevelVar.Set.
 func (l *implLogger) SetLevel(level ilog.Level) ilog.Logger { //nolint:ireturn
 	copied := l.copy()
+	copied.levelVar = nil
 	copied.level = level
 	return copied
 }
@@ -49,6 +87,30 @@ func (l *implLogger) Copy() ilog.Logger { //nolint:ireturn
 	return l.copy()
 }
 
+// Sync calls (*zap.Logger).Sync.
+func (l *implLogger) Sync() error {
+	return l.zapLogger.Sync() //nolint:wrapcheck
+}
+
+// SetLevelRegistry returns a copy of the logger whose descendants named by Named use the levels configured in the LevelRegistry.
+func SetLevelRegistry(ilogZap ilog.Logger, r *ilog.LevelRegistry) ilog.Logger { //nolint:ireturn
+	il, ok := ilogZap.(*implLogger)
+	if !ok {
+		return ilogZap
+	}
+	copied := il.copy()
+	copied.levelRegistry = r
+	return copied
+}
+
+func (l *implLogger) Named(name string) ilog.Logger { //nolint:ireturn
+	copied := l.copy()
+	copied.name = joinName(l.name, name)
+	copied.zapLogger = l.zapLogger.Named(name)
+	copied.levelVar = lookupLevelVar(copied.levelRegistry, copied.name, copied.levelVar)
+	return copied
+}
+
 func (l *implLogger) copy() *implLogger {
 	copied := *l
 	copied.zapLogger = l.zapLogger.WithOptions() // NOTE: call (*zap.Logger).clone() internally
@@ -119,6 +181,65 @@ func (l *implLogger) Uint64(key string, value uint64) ilog.LogEntry { //nolint:i
 	return l.new().Uint64(key, value)
 }
 
+func (l *implLogger) Object(key string, marshaler ilog.ObjectMarshaler) ilog.LogEntry { //nolint:ireturn
+	return l.new().Object(key, marshaler)
+}
+
+func (l *implLogger) Group(name string) ilog.LogEntry { //nolint:ireturn
+	return l.new().Group(name)
+}
+
+func (l *implLogger) Array(key string, marshaler ilog.ArrayMarshaler) ilog.LogEntry { //nolint:ireturn
+	return l.new().Array(key, marshaler)
+}
+
+func (l *implLogger) Bools(key string, values []bool) ilog.LogEntry { //nolint:ireturn
+	return l.new().Bools(key, values)
+}
+
+func (l *implLogger) Durations(key string, values []time.Duration) ilog.LogEntry { //nolint:ireturn
+	return l.new().Durations(key, values)
+}
+
+func (l *implLogger) Errs(key string, errs []error) ilog.LogEntry { //nolint:ireturn
+	return l.new().Errs(key, errs)
+}
+
+func (l *implLogger) Float64s(key string, values []float64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Float64s(key, values)
+}
+
+func (l *implLogger) Ints(key string, values []int) ilog.LogEntry { //nolint:ireturn
+	return l.new().Ints(key, values)
+}
+
+func (l *implLogger) Int64s(key string, values []int64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Int64s(key, values)
+}
+
+func (l *implLogger) Strings(key string, values []string) ilog.LogEntry { //nolint:ireturn
+	return l.new().Strings(key, values)
+}
+
+func (l *implLogger) Times(key string, values []time.Time) ilog.LogEntry { //nolint:ireturn
+	return l.new().Times(key, values)
+}
+
+func (l *implLogger) Stack(key string) ilog.LogEntry { //nolint:ireturn
+	e := l.new()
+	// NOTE: skip implLogger.Stack
+	e.fields = append(e.fields, zap.StackSkip(key, 1))
+	return e
+}
+
+func (l *implLogger) Ctx(ctx context.Context) ilog.LogEntry { //nolint:ireturn
+	return ilog.ExtractContext(ctx, l.new())
+}
+
+func (l *implLogger) Tracef(format string, args ...interface{}) {
+	l.new().logf(ilog.TraceLevel, format, args...)
+}
+
 func (l *implLogger) Debugf(format string, args ...interface{}) {
 	l.new().logf(ilog.DebugLevel, format, args...)
 }
@@ -135,12 +256,24 @@ func (l *implLogger) Errorf(format string, args ...interface{}) {
 	l.new().logf(ilog.ErrorLevel, format, args...)
 }
 
+func (l *implLogger) Panicf(format string, args ...interface{}) {
+	l.new().logf(ilog.PanicLevel, format, args...)
+	_ = l.Sync()
+	panic(sprintf(format, args...))
+}
+
+func (l *implLogger) Fatalf(format string, args ...interface{}) {
+	l.new().logf(ilog.FatalLevel, format, args...)
+	_ = l.Sync()
+	ilog.Exit(1)
+}
+
 func (l *implLogger) Logf(level ilog.Level, format string, args ...interface{}) {
 	l.new().logf(level, format, args...)
 }
 
 func (l *implLogger) Write(p []byte) (int, error) {
-	l.new().logf(l.level, string(p))
+	l.new().logf(l.Level(), string(p))
 	return len(p), nil
 }
 
@@ -241,6 +374,69 @@ func (e *implLogEntry) Uint64(key string, value uint64) ilog.LogEntry { //nolint
 	return e
 }
 
+// Object adds the nested object. If the marshaler is nil, null is added as the default implementation does.
+func (e *implLogEntry) Object(key string, marshaler ilog.ObjectMarshaler) ilog.LogEntry { //nolint:ireturn
+	e.fields = append(e.fields, objectField(key, marshaler))
+	return e
+}
+
+func (e *implLogEntry) Group(name string) ilog.LogEntry { //nolint:ireturn
+	e.fields = append(e.fields, zap.Namespace(name))
+	return e
+}
+
+// Array adds the array. If the marshaler is nil, null is added as the default implementation does.
+func (e *implLogEntry) Array(key string, marshaler ilog.ArrayMarshaler) ilog.LogEntry { //nolint:ireturn
+	e.fields = append(e.fields, arrayField(key, marshaler))
+	return e
+}
+
+func (e *implLogEntry) Bools(key string, values []bool) ilog.LogEntry { //nolint:ireturn
+	e.fields = append(e.fields, zap.Bools(key, values))
+	return e
+}
+
+func (e *implLogEntry) Durations(key string, values []time.Duration) ilog.LogEntry { //nolint:ireturn
+	e.fields = append(e.fields, zap.Durations(key, values))
+	return e
+}
+
+func (e *implLogEntry) Errs(key string, errs []error) ilog.LogEntry { //nolint:ireturn
+	e.fields = append(e.fields, zap.Errors(key, errs))
+	return e
+}
+
+func (e *implLogEntry) Float64s(key string, values []float64) ilog.LogEntry { //nolint:ireturn
+	e.fields = append(e.fields, zap.Float64s(key, values))
+	return e
+}
+
+func (e *implLogEntry) Ints(key string, values []int) ilog.LogEntry { //nolint:ireturn
+	e.fields = append(e.fields, zap.Ints(key, values))
+	return e
+}
+
+func (e *implLogEntry) Int64s(key string, values []int64) ilog.LogEntry { //nolint:ireturn
+	e.fields = append(e.fields, zap.Int64s(key, values))
+	return e
+}
+
+func (e *implLogEntry) Strings(key string, values []string) ilog.LogEntry { //nolint:ireturn
+	e.fields = append(e.fields, zap.Strings(key, values))
+	return e
+}
+
+func (e *implLogEntry) Times(key string, values []time.Time) ilog.LogEntry { //nolint:ireturn
+	e.fields = append(e.fields, zap.Times(key, values))
+	return e
+}
+
+func (e *implLogEntry) Stack(key string) ilog.LogEntry { //nolint:ireturn
+	// NOTE: skip implLogEntry.Stack
+	e.fields = append(e.fields, zap.StackSkip(key, 1))
+	return e
+}
+
 func (e *implLogEntry) Logger() ilog.Logger { //nolint:ireturn
 	copied := e.logger.copy()
 	copied.zapLogger = copied.zapLogger.With(e.fields...)
@@ -248,10 +444,18 @@ func (e *implLogEntry) Logger() ilog.Logger { //nolint:ireturn
 }
 
 func (e *implLogEntry) Write(p []byte) (int, error) {
-	e.logf(e.logger.level, string(p))
+	e.logf(e.logger.Level(), string(p))
 	return len(p), nil
 }
 
+func (e *implLogEntry) Ctx(ctx context.Context) ilog.LogEntry { //nolint:ireturn
+	return ilog.ExtractContext(ctx, e)
+}
+
+func (e *implLogEntry) Tracef(format string, args ...interface{}) {
+	e.logf(ilog.TraceLevel, format, args...)
+}
+
 func (e *implLogEntry) Debugf(format string, args ...interface{}) {
 	e.logf(ilog.DebugLevel, format, args...)
 }
@@ -268,38 +472,61 @@ func (e *implLogEntry) Errorf(format string, args ...interface{}) {
 	e.logf(ilog.ErrorLevel, format, args...)
 }
 
+func (e *implLogEntry) Panicf(format string, args ...interface{}) {
+	e.logf(ilog.PanicLevel, format, args...)
+	_ = e.logger.Sync()
+	panic(sprintf(format, args...))
+}
+
+func (e *implLogEntry) Fatalf(format string, args ...interface{}) {
+	e.logf(ilog.FatalLevel, format, args...)
+	_ = e.logger.Sync()
+	ilog.Exit(1)
+}
+
 func (e *implLogEntry) Logf(level ilog.Level, format string, args ...interface{}) {
 	e.logf(level, format, args...)
 }
 
 func (e *implLogEntry) logf(level ilog.Level, format string, args ...interface{}) {
-	if level < e.logger.level {
+	if level < e.logger.Level() {
 		return
 	}
 	defer func() {
 		e.fields = make([]zap.Field, 0)
 	}()
-	switch level { //nolint:exhaustive
-	case ilog.InfoLevel:
+	// NOTE: a level between the predefined levels is logged at the nearest lower level of zap.
+	switch {
+	case level >= ilog.PanicLevel:
+		zapLevel := zapcore.PanicLevel
+		if level >= ilog.FatalLevel {
+			zapLevel = zapcore.FatalLevel
+		}
+		if ce := e.logger.zapLogger.Check(zapLevel, sprintf(format, args...)); ce != nil {
+			// NOTE: zap panics or exits after writing at these levels. Panicf and Fatalf do it instead.
+			ce.After(ce.Entry, zapcore.WriteThenNoop).Write(e.fields...)
+		}
+		return
+	case level >= ilog.ErrorLevel:
 		if len(args) > 0 {
-			e.logger.zapLogger.With(e.fields...).Sugar().Infof(format, args...)
+			e.logger.zapLogger.With(e.fields...).Sugar().Errorf(format, args...)
 			return
 		}
-		e.logger.zapLogger.Info(format, e.fields...)
+		e.logger.zapLogger.Error(format, e.fields...)
 		return
-	case ilog.WarnLevel:
+	case level >= ilog.WarnLevel:
 		if len(args) > 0 {
 			e.logger.zapLogger.With(e.fields...).Sugar().Warnf(format, args...)
 			return
 		}
 		e.logger.zapLogger.Warn(format, e.fields...)
 		return
-	case ilog.ErrorLevel:
+	case level >= ilog.InfoLevel:
 		if len(args) > 0 {
-			e.logger.zapLogger.With(e.fields...).Sugar().Errorf(format, args...)
+			e.logger.zapLogger.With(e.fields...).Sugar().Infof(format, args...)
 			return
 		}
-		e.logger.zapLogger.Error(format, e.fields...)
+		e.logger.zapLogger.Info(format, e.fields...)
 		return
 	default:
 		if len(args) > 0 {
@@ -310,3 +537,334 @@ func (e *implLogEntry) logf(level ilog.Level, format string, args ...interface{}
 		return
 	}
 }
+
+// objectField returns the field of the nested object, or null if the marshaler is nil.
+func objectField(key string, marshaler ilog.ObjectMarshaler) zap.Field {
+	if marshaler == nil {
+		return zap.Reflect(key, nil)
+	}
+	return zap.Object(key, objectMarshaler{marshaler})
+}
+
+// arrayField returns the field of the array, or null if the marshaler is nil.
+func arrayField(key string, marshaler ilog.ArrayMarshaler) zap.Field {
+	if marshaler == nil {
+		return zap.Reflect(key, nil)
+	}
+	return zap.Array(key, arrayMarshaler{marshaler})
+}
+
+// objectMarshaler is the zapcore.ObjectMarshaler that adds the fields of ilog.ObjectMarshaler.
+type objectMarshaler struct {
+	marshaler ilog.ObjectMarshaler
+}
+
+// MarshalLogObject returns the panic of the marshaler as an error, such as the one caused by a typed nil marshaler, since zap does not recover it.
+func (m objectMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("ilog: MarshalLogObject panicked: %v", p) //nolint:goerr113
+		}
+	}()
+	return m.marshaler.MarshalLogObject(objectEncoder{enc}) //nolint:wrapcheck
+}
+
+// objectEncoder is the ilog.ObjectEncoder that adds fields to zapcore.ObjectEncoder.
+type objectEncoder struct {
+	enc zapcore.ObjectEncoder
+}
+
+func (o objectEncoder) AddAny(key string, value interface{}) {
+	zap.Any(key, value).AddTo(o.enc)
+}
+
+func (o objectEncoder) AddBool(key string, value bool) {
+	o.enc.AddBool(key, value)
+}
+
+func (o objectEncoder) AddBytes(key string, value []byte) {
+	o.enc.AddByteString(key, value)
+}
+
+func (o objectEncoder) AddDuration(key string, value time.Duration) {
+	o.enc.AddDuration(key, value)
+}
+
+func (o objectEncoder) AddErr(key string, err error) {
+	zap.NamedError(key, err).AddTo(o.enc)
+}
+
+func (o objectEncoder) AddFloat32(key string, value float32) {
+	o.enc.AddFloat32(key, value)
+}
+
+func (o objectEncoder) AddFloat64(key string, value float64) {
+	o.enc.AddFloat64(key, value)
+}
+
+func (o objectEncoder) AddInt(key string, value int) {
+	o.enc.AddInt(key, value)
+}
+
+func (o objectEncoder) AddInt32(key string, value int32) {
+	o.enc.AddInt32(key, value)
+}
+
+func (o objectEncoder) AddInt64(key string, value int64) {
+	o.enc.AddInt64(key, value)
+}
+
+func (o objectEncoder) AddObject(key string, marshaler ilog.ObjectMarshaler) {
+	objectField(key, marshaler).AddTo(o.enc)
+}
+
+func (o objectEncoder) AddString(key, value string) {
+	o.enc.AddString(key, value)
+}
+
+func (o objectEncoder) AddTime(key string, value time.Time) {
+	o.enc.AddTime(key, value)
+}
+
+func (o objectEncoder) AddUint(key string, value uint) {
+	o.enc.AddUint(key, value)
+}
+
+func (o objectEncoder) AddUint32(key string, value uint32) {
+	o.enc.AddUint32(key, value)
+}
+
+func (o objectEncoder) AddUint64(key string, value uint64) {
+	o.enc.AddUint64(key, value)
+}
+
+func (o objectEncoder) AddArray(key string, marshaler ilog.ArrayMarshaler) {
+	arrayField(key, marshaler).AddTo(o.enc)
+}
+
+func (o objectEncoder) AddBools(key string, values []bool) {
+	zap.Bools(key, values).AddTo(o.enc)
+}
+
+func (o objectEncoder) AddDurations(key string, values []time.Duration) {
+	zap.Durations(key, values).AddTo(o.enc)
+}
+
+func (o objectEncoder) AddErrs(key string, errs []error) {
+	zap.Errors(key, errs).AddTo(o.enc)
+}
+
+func (o objectEncoder) AddFloat64s(key string, values []float64) {
+	zap.Float64s(key, values).AddTo(o.enc)
+}
+
+func (o objectEncoder) AddInts(key string, values []int) {
+	zap.Ints(key, values).AddTo(o.enc)
+}
+
+func (o objectEncoder) AddInt64s(key string, values []int64) {
+	zap.Int64s(key, values).AddTo(o.enc)
+}
+
+func (o objectEncoder) AddStrings(key string, values []string) {
+	zap.Strings(key, values).AddTo(o.enc)
+}
+
+func (o objectEncoder) AddTimes(key string, values []time.Time) {
+	zap.Times(key, values).AddTo(o.enc)
+}
+
+// arrayMarshaler is the zapcore.ArrayMarshaler that appends the elements of ilog.ArrayMarshaler.
+type arrayMarshaler struct {
+	marshaler ilog.ArrayMarshaler
+}
+
+// MarshalLogArray returns the panic of the marshaler as an error, such as the one caused by a typed nil marshaler, since zap does not recover it.
+func (m arrayMarshaler) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("ilog: MarshalLogArray panicked: %v", p) //nolint:goerr113
+		}
+	}()
+	return m.marshaler.MarshalLogArray(arrayEncoder{enc}) //nolint:wrapcheck
+}
+
+// arrayEncoder is the ilog.ArrayEncoder that appends elements to zapcore.ArrayEncoder.
+type arrayEncoder struct {
+	enc zapcore.ArrayEncoder
+}
+
+func (a arrayEncoder) AppendAny(value interface{}) {
+	_ = a.enc.AppendReflected(value)
+}
+
+func (a arrayEncoder) AppendArray(marshaler ilog.ArrayMarshaler) {
+	if marshaler == nil {
+		_ = a.enc.AppendReflected(nil)
+		return
+	}
+	_ = a.enc.AppendArray(arrayMarshaler{marshaler})
+}
+
+func (a arrayEncoder) AppendBool(value bool) {
+	a.enc.AppendBool(value)
+}
+
+func (a arrayEncoder) AppendBytes(value []byte) {
+	a.enc.AppendByteString(value)
+}
+
+func (a arrayEncoder) AppendDuration(value time.Duration) {
+	a.enc.AppendDuration(value)
+}
+
+func (a arrayEncoder) AppendErr(err error) {
+	if err == nil {
+		_ = a.enc.AppendReflected(nil)
+		return
+	}
+	a.enc.AppendString(err.Error())
+}
+
+func (a arrayEncoder) AppendFloat32(value float32) {
+	a.enc.AppendFloat32(value)
+}
+
+func (a arrayEncoder) AppendFloat64(value float64) {
+	a.enc.AppendFloat64(value)
+}
+
+func (a arrayEncoder) AppendInt(value int) {
+	a.enc.AppendInt(value)
+}
+
+func (a arrayEncoder) AppendInt32(value int32) {
+	a.enc.AppendInt32(value)
+}
+
+func (a arrayEncoder) AppendInt64(value int64) {
+	a.enc.AppendInt64(value)
+}
+
+func (a arrayEncoder) AppendObject(marshaler ilog.ObjectMarshaler) {
+	if marshaler == nil {
+		_ = a.enc.AppendReflected(nil)
+		return
+	}
+	_ = a.enc.AppendObject(objectMarshaler{marshaler})
+}
+
+func (a arrayEncoder) AppendString(value string) {
+	a.enc.AppendString(value)
+}
+
+func (a arrayEncoder) AppendTime(value time.Time) {
+	a.enc.AppendTime(value)
+}
+
+func (a arrayEncoder) AppendUint(value uint) {
+	a.enc.AppendUint(value)
+}
+
+func (a arrayEncoder) AppendUint32(value uint32) {
+	a.enc.AppendUint32(value)
+}
+
+func (a arrayEncoder) AppendUint64(value uint64) {
+	a.enc.AppendUint64(value)
+}
+
+// hookCore is zapcore.Core that calls ilog.Hook instead of writing log entries.
+type hookCore struct {
+	zapcore.LevelEnabler
+	fields []zapcore.Field
+	hooks  []ilog.Hook
+}
+
+func (c *hookCore) With(fields []zapcore.Field) zapcore.Core { //nolint:ireturn
+	copied := *c
+	copied.fields = append(c.fields[:len(c.fields):len(c.fields)], fields...)
+	return &copied
+}
+
+func (c *hookCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *hookCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, f := range c.fields {
+		f.AddTo(enc)
+	}
+	for _, f := range fields {
+		f.AddTo(enc)
+	}
+
+	entry := ilog.HookEntry{
+		Level:      fromZapLevel(ent.Level),
+		Message:    ent.Message,
+		Timestamp:  ent.Time,
+		CallerFile: ent.Caller.File,
+		CallerLine: ent.Caller.Line,
+		Fields:     enc.Fields,
+	}
+	for _, hook := range c.hooks {
+		hook.Run(entry)
+	}
+	return nil
+}
+
+func (c *hookCore) Sync() error {
+	return nil
+}
+
+func fromZapLevel(level zapcore.Level) ilog.Level {
+	switch level { //nolint:exhaustive
+	case zapcore.DebugLevel:
+		return ilog.DebugLevel
+	case zapcore.InfoLevel:
+		return ilog.InfoLevel
+	case zapcore.WarnLevel:
+		return ilog.WarnLevel
+	case zapcore.DPanicLevel, zapcore.PanicLevel:
+		return ilog.PanicLevel
+	case zapcore.FatalLevel:
+		return ilog.FatalLevel
+	default:
+		return ilog.ErrorLevel
+	}
+}
+
+// joinName returns the name of a child logger, e.g. "db.pool" for "db" and "pool".
+func joinName(parent, name string) string {
+	switch {
+	case parent == "":
+		return name
+	case name == "":
+		return parent
+	default:
+		return parent + "." + name
+	}
+}
+
+// lookupLevelVar returns the LevelVar configured for the name in the registry, or current if it is not configured.
+func lookupLevelVar(r *ilog.LevelRegistry, name string, current *ilog.LevelVar) *ilog.LevelVar {
+	if r == nil {
+		return current
+	}
+	if v := r.LevelVar(name); v != nil {
+		return v
+	}
+	return current
+}
+
+// sprintf returns format as it is if args is empty, otherwise fmt.Sprintf(format, args...).
+func sprintf(format string, args ...interface{}) string {
+	if len(args) > 0 {
+		return fmt.Sprintf(format, args...)
+	}
+	return format
+}
@@ -1,6 +1,13 @@
 package zerolog
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -18,9 +25,29 @@ func FromLogger(ilogZerolog ilog.Logger) *zerolog.Logger {
 
 type implLogger struct {
 	level         ilog.Level
+	levelVar      *ilog.LevelVar
+	levelRegistry *ilog.LevelRegistry
+	name          string
 	zerologLogger *zerolog.Logger
+	// groups is the groups opened by Group and not closed yet.
+	groups []group
+	hooks  []ilog.Hook
+	syncer ilog.Syncer
+	// callerSkip is the sum of the skips passed to AddCallerSkip. It is used to find the caller for the hooks.
+	callerSkip int
+	// fields is the fields added to the logger after AddHook is called. Since zerolog.Context cannot be read, they are kept for the hooks.
+	fields []field
 }
 
+// group is a nested object opened by Group. Since zerolog.Context cannot open a nested object, its fields are kept until the log entry is written.
+type group struct {
+	name   string
+	fields []field
+}
+
+// field adds a field to zerolog.Context or zerolog.Event.
+type field func(enc encoder)
+
 func New(level ilog.Level, l zerolog.Logger) ilog.Logger { //nolint:ireturn
 	return &implLogger{
 		level:         level,
@@ -28,18 +55,61 @@ func New(level ilog.Level, l zerolog.Logger) ilog.Logger { //nolint:ireturn
 	}
 }
 
+// AddHook returns a copy of the logger that calls the hooks for every log entry written by the logger.
+// The hooks are called synchronously after the log entry is written. The fields added to the logger before AddHook is called are not passed to the hooks.
+func AddHook(ilogZerolog ilog.Logger, hooks ...ilog.Hook) ilog.Logger { //nolint:ireturn
+	il, ok := ilogZerolog.(*implLogger)
+	if !ok {
+		return ilogZerolog
+	}
+	copied := il.copy()
+	copied.hooks = append(il.hooks[:len(il.hooks):len(il.hooks)], hooks...)
+	return copied
+}
+
+// SetSyncer returns a copy of the logger whose Sync calls syncer.Sync.
+// Since zerolog.Logger does not expose its writer, pass the writer passed to zerolog.New if it implements ilog.Syncer, such as *os.File.
+func SetSyncer(ilogZerolog ilog.Logger, syncer ilog.Syncer) ilog.Logger { //nolint:ireturn
+	il, ok := ilogZerolog.(*implLogger)
+	if !ok {
+		return ilogZerolog
+	}
+	copied := il.copy()
+	copied.syncer = syncer
+	return copied
+}
+
+// SetLevelVar returns a copy of the logger that reads its level from the LevelVar instead of the level passed to New.
+// The LevelVar is shared by the copies of the logger, so changing it changes the level of all of them at runtime.
+func SetLevelVar(ilogZerolog ilog.Logger, v *ilog.LevelVar) ilog.Logger { //nolint:ireturn
+	il, ok := ilogZerolog.(*implLogger)
+	if !ok {
+		return ilogZerolog
+	}
+	copied := il.copy()
+	copied.levelVar = v
+	return copied
+}
+
 func (l *implLogger) Level() ilog.Level {
+	if l.levelVar != nil {
+		return l.levelVar.Level()
+	}
 	return l.level
 }
 
+// SetLevel returns a copy of the logger with the level.
+// If the logger has a LevelVar set by SetLevelVar, the copy no longer reads it, so the level of the other loggers sharing it is not changed. To change all of them, use LevelVar.Set.
 func (l *implLogger) SetLevel(level ilog.Level) ilog.Logger { //nolint:ireturn
 	copied := l.copy()
+	copied.levelVar = nil
 	copied.level = level
 	return copied
 }
 
 func (l *implLogger) AddCallerSkip(skip int) ilog.Logger { //nolint:ireturn
 	copied := l.copy()
+	copied.callerSkip += skip
 	logger := l.zerologLogger.With().Caller().CallerWithSkipFrameCount(skip).Logger()
 	copied.zerologLogger = &logger
 	return copied
@@ -49,6 +119,32 @@ func (l *implLogger) Copy() ilog.Logger { //nolint:ireturn
 	return l.copy()
 }
 
+// Sync calls Sync of the syncer set by SetSyncer. If no syncer is set, it does nothing.
+func (l *implLogger) Sync() error {
+	if l.syncer == nil {
+		return nil
+	}
+	return l.syncer.Sync() //nolint:wrapcheck
+}
+
+// SetLevelRegistry returns a copy of the logger whose descendants named by Named use the levels configured in the LevelRegistry.
+func SetLevelRegistry(ilogZerolog ilog.Logger, r *ilog.LevelRegistry) ilog.Logger { //nolint:ireturn
+	il, ok := ilogZerolog.(*implLogger)
+	if !ok {
+		return ilogZerolog
+	}
+	copied := il.copy()
+	copied.levelRegistry = r
+	return copied
+}
+
+func (l *implLogger) Named(name string) ilog.Logger { //nolint:ireturn
+	copied := l.copy()
+	copied.name = joinName(l.name, name)
+	copied.levelVar = lookupLevelVar(copied.levelRegistry, copied.name, copied.levelVar)
+	return copied
+}
+
 func (l *implLogger) copy() *implLogger {
 	copied := *l
 	copiedZerologLogger := *l.zerologLogger
@@ -120,6 +216,64 @@ func (l *implLogger) Uint64(key string, value uint64) ilog.LogEntry { //nolint:i
 	return l.new().Uint64(key, value)
 }
 
+func (l *implLogger) Object(key string, marshaler ilog.ObjectMarshaler) ilog.LogEntry { //nolint:ireturn
+	return l.new().Object(key, marshaler)
+}
+
+func (l *implLogger) Group(name string) ilog.LogEntry { //nolint:ireturn
+	return l.new().Group(name)
+}
+
+func (l *implLogger) Array(key string, marshaler ilog.ArrayMarshaler) ilog.LogEntry { //nolint:ireturn
+	return l.new().Array(key, marshaler)
+}
+
+func (l *implLogger) Bools(key string, values []bool) ilog.LogEntry { //nolint:ireturn
+	return l.new().Bools(key, values)
+}
+
+func (l *implLogger) Durations(key string, values []time.Duration) ilog.LogEntry { //nolint:ireturn
+	return l.new().Durations(key, values)
+}
+
+func (l *implLogger) Errs(key string, errs []error) ilog.LogEntry { //nolint:ireturn
+	return l.new().Errs(key, errs)
+}
+
+func (l *implLogger) Float64s(key string, values []float64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Float64s(key, values)
+}
+
+func (l *implLogger) Ints(key string, values []int) ilog.LogEntry { //nolint:ireturn
+	return l.new().Ints(key, values)
+}
+
+func (l *implLogger) Int64s(key string, values []int64) ilog.LogEntry { //nolint:ireturn
+	return l.new().Int64s(key, values)
+}
+
+func (l *implLogger) Strings(key string, values []string) ilog.LogEntry { //nolint:ireturn
+	return l.new().Strings(key, values)
+}
+
+func (l *implLogger) Times(key string, values []time.Time) ilog.LogEntry { //nolint:ireturn
+	return l.new().Times(key, values)
+}
+
+func (l *implLogger) Stack(key string) ilog.LogEntry { //nolint:ireturn
+	// NOTE: skip runtime.Callers, stacktrace and implLogger.Stack
+	const skip = 3
+	return l.new().stack(key, stacktrace(skip))
+}
+
+func (l *implLogger) Ctx(ctx context.Context) ilog.LogEntry { //nolint:ireturn
+	return ilog.ExtractContext(ctx, l.new())
+}
+
+func (l *implLogger) Tracef(format string, args ...interface{}) {
+	l.new().logf(ilog.TraceLevel, format, args...)
+}
+
 func (l *implLogger) Debugf(format string, args ...interface{}) {
 	l.new().logf(ilog.DebugLevel, format, args...)
 }
@@ -136,160 +290,301 @@ func (l *implLogger) Errorf(format string, args ...interface{}) {
 	l.new().logf(ilog.ErrorLevel, format, args...)
 }
 
+func (l *implLogger) Panicf(format string, args ...interface{}) {
+	l.new().logf(ilog.PanicLevel, format, args...)
+	_ = l.Sync()
+	panic(sprintf(format, args...))
+}
+
+func (l *implLogger) Fatalf(format string, args ...interface{}) {
+	l.new().logf(ilog.FatalLevel, format, args...)
+	_ = l.Sync()
+	ilog.Exit(1)
+}
+
 func (l *implLogger) Logf(level ilog.Level, format string, args ...interface{}) {
 	l.new().logf(level, format, args...)
 }
 
 func (l *implLogger) Write(p []byte) (int, error) {
-	l.new().logf(l.level, string(p))
+	l.new().logf(l.Level(), string(p))
 	return len(p), nil
 }
 
 func (l *implLogger) new() *implLogEntry {
-	return &implLogEntry{
+	e := &implLogEntry{
 		logger: l,
 	}
+	if len(l.groups) > 0 {
+		e.groups = make([]group, len(l.groups))
+		for i, g := range l.groups {
+			// NOTE: cap the fields so that appending to them does not modify the groups of the logger.
+			e.groups[i] = group{name: g.name, fields: g.fields[:len(g.fields):len(g.fields)]}
+		}
+	}
+	return e
 }
 
 //nolint:errname
 type implLogEntry struct {
 	logger *implLogger
-	zCtxs  []func(e zerolog.Context) zerolog.Context
+	fields []field
+	groups []group
 }
 
 func (*implLogEntry) Error() string {
 	return ilog.ErrLogEntryIsNotWritten.Error()
 }
 
+// add adds the field to the innermost open group, or to the log entry if no group is open.
+func (e *implLogEntry) add(f field) {
+	if n := len(e.groups); n > 0 {
+		e.groups[n-1].fields = append(e.groups[n-1].fields, f)
+		return
+	}
+	e.fields = append(e.fields, f)
+}
+
 func (e *implLogEntry) Any(key string, value interface{}) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Interface(key, value)
+	e.add(func(enc encoder) {
+		enc.AddAny(key, value)
 	})
 	return e
 }
 
 func (e *implLogEntry) Bool(key string, value bool) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Bool(key, value)
+	e.add(func(enc encoder) {
+		enc.AddBool(key, value)
 	})
 	return e
 }
 
 func (e *implLogEntry) Bytes(key string, value []byte) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Bytes(key, value)
+	e.add(func(enc encoder) {
+		enc.AddBytes(key, value)
 	})
 	return e
 }
 
 func (e *implLogEntry) Duration(key string, value time.Duration) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Dur(key, value)
+	e.add(func(enc encoder) {
+		enc.AddDuration(key, value)
 	})
 	return e
 }
 
 func (e *implLogEntry) Err(err error) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Err(err)
+	e.add(func(enc encoder) {
+		enc.addErr(err)
 	})
 	return e
 }
 
 func (e *implLogEntry) ErrWithKey(key string, err error) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.AnErr(key, err)
+	e.add(func(enc encoder) {
+		enc.AddErr(key, err)
 	})
 	return e
 }
 
 func (e *implLogEntry) Float32(key string, value float32) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Float32(key, value)
+	e.add(func(enc encoder) {
+		enc.AddFloat32(key, value)
 	})
 	return e
 }
 
 func (e *implLogEntry) Float64(key string, value float64) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Float64(key, value)
+	e.add(func(enc encoder) {
+		enc.AddFloat64(key, value)
 	})
 	return e
 }
 
 func (e *implLogEntry) Int(key string, value int) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Int(key, value)
+	e.add(func(enc encoder) {
+		enc.AddInt(key, value)
 	})
 	return e
 }
 
 func (e *implLogEntry) Int32(key string, value int32) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Int32(key, value)
+	e.add(func(enc encoder) {
+		enc.AddInt32(key, value)
 	})
 	return e
 }
 
 func (e *implLogEntry) Int64(key string, value int64) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Int64(key, value)
+	e.add(func(enc encoder) {
+		enc.AddInt64(key, value)
 	})
 	return e
 }
 
 func (e *implLogEntry) String(key, value string) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Str(key, value)
+	e.add(func(enc encoder) {
+		enc.AddString(key, value)
 	})
 	return e
 }
 
 func (e *implLogEntry) Time(key string, value time.Time) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Time(key, value)
+	e.add(func(enc encoder) {
+		enc.AddTime(key, value)
 	})
 	return e
 }
 
 func (e *implLogEntry) Uint(key string, value uint) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Uint(key, value)
+	e.add(func(enc encoder) {
+		enc.AddUint(key, value)
 	})
 	return e
 }
 
 func (e *implLogEntry) Uint32(key string, value uint32) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Uint32(key, value)
+	e.add(func(enc encoder) {
+		enc.AddUint32(key, value)
 	})
 	return e
 }
 
 func (e *implLogEntry) Uint64(key string, value uint64) ilog.LogEntry { //nolint:ireturn
-	e.zCtxs = append(e.zCtxs, func(e zerolog.Context) zerolog.Context {
-		return e.Uint64(key, value)
+	e.add(func(enc encoder) {
+		enc.AddUint64(key, value)
+	})
+	return e
+}
+
+// Object adds the nested object. If the marshaler is nil, null is added as the default implementation does.
+func (e *implLogEntry) Object(key string, marshaler ilog.ObjectMarshaler) ilog.LogEntry { //nolint:ireturn
+	e.add(func(enc encoder) {
+		enc.AddObject(key, marshaler)
+	})
+	return e
+}
+
+// Array adds the array. If the marshaler is nil, null is added as the default implementation does.
+func (e *implLogEntry) Array(key string, marshaler ilog.ArrayMarshaler) ilog.LogEntry { //nolint:ireturn
+	e.add(func(enc encoder) {
+		enc.AddArray(key, marshaler)
+	})
+	return e
+}
+
+func (e *implLogEntry) Bools(key string, values []bool) ilog.LogEntry { //nolint:ireturn
+	e.add(func(enc encoder) {
+		enc.AddBools(key, values)
+	})
+	return e
+}
+
+func (e *implLogEntry) Durations(key string, values []time.Duration) ilog.LogEntry { //nolint:ireturn
+	e.add(func(enc encoder) {
+		enc.AddDurations(key, values)
+	})
+	return e
+}
+
+func (e *implLogEntry) Errs(key string, errs []error) ilog.LogEntry { //nolint:ireturn
+	e.add(func(enc encoder) {
+		enc.AddErrs(key, errs)
+	})
+	return e
+}
+
+func (e *implLogEntry) Float64s(key string, values []float64) ilog.LogEntry { //nolint:ireturn
+	e.add(func(enc encoder) {
+		enc.AddFloat64s(key, values)
 	})
 	return e
 }
 
+func (e *implLogEntry) Ints(key string, values []int) ilog.LogEntry { //nolint:ireturn
+	e.add(func(enc encoder) {
+		enc.AddInts(key, values)
+	})
+	return e
+}
+
+func (e *implLogEntry) Int64s(key string, values []int64) ilog.LogEntry { //nolint:ireturn
+	e.add(func(enc encoder) {
+		enc.AddInt64s(key, values)
+	})
+	return e
+}
+
+func (e *implLogEntry) Strings(key string, values []string) ilog.LogEntry { //nolint:ireturn
+	e.add(func(enc encoder) {
+		enc.AddStrings(key, values)
+	})
+	return e
+}
+
+func (e *implLogEntry) Times(key string, values []time.Time) ilog.LogEntry { //nolint:ireturn
+	e.add(func(enc encoder) {
+		enc.AddTimes(key, values)
+	})
+	return e
+}
+
+func (e *implLogEntry) Stack(key string) ilog.LogEntry { //nolint:ireturn
+	// NOTE: skip runtime.Callers, stacktrace and implLogEntry.Stack
+	const skip = 3
+	return e.stack(key, stacktrace(skip))
+}
+
+func (e *implLogEntry) stack(key, stack string) ilog.LogEntry { //nolint:ireturn
+	e.add(func(enc encoder) {
+		enc.AddString(key, stack)
+	})
+	return e
+}
+
+func (e *implLogEntry) Group(name string) ilog.LogEntry { //nolint:ireturn
+	e.groups = append(e.groups, group{name: name})
+	return e
+}
+
+// withFields returns zerolog.Context that has the fields of the log entry which are not in any group.
+func (e *implLogEntry) withFields(c zerolog.Context) zerolog.Context {
+	return withFields(c, e.fields)
+}
+
+func withFields(c zerolog.Context, fields []field) zerolog.Context {
+	enc := &contextEncoder{c: c}
+	for _, f := range fields {
+		f(enc)
+	}
+	return enc.c
+}
+
 func (e *implLogEntry) Logger() ilog.Logger { //nolint:ireturn
 	copied := e.logger.copy()
-	c := copied.zerologLogger.With()
-	for _, event := range e.zCtxs {
-		c = event(c)
-	}
-	logger := c.Logger()
+	logger := e.withFields(copied.zerologLogger.With()).Logger()
 	copied.zerologLogger = &logger
+	copied.groups = e.groups[:len(e.groups):len(e.groups)]
+	if len(copied.hooks) > 0 {
+		copied.fields = append(e.logger.fields[:len(e.logger.fields):len(e.logger.fields)], e.fields...)
+	}
 
 	return copied
 }
 
 func (e *implLogEntry) Write(p []byte) (n int, err error) {
-	e.logf(e.logger.level, string(p))
+	e.logf(e.logger.Level(), string(p))
 	return len(p), nil
 }
 
+func (e *implLogEntry) Ctx(ctx context.Context) ilog.LogEntry { //nolint:ireturn
+	return ilog.ExtractContext(ctx, e)
+}
+
+func (e *implLogEntry) Tracef(format string, args ...interface{}) {
+	e.logf(ilog.TraceLevel, format, args...)
+}
+
 func (e *implLogEntry) Debugf(format string, args ...interface{}) {
 	e.logf(ilog.DebugLevel, format, args...)
 }
@@ -306,67 +601,560 @@ func (e *implLogEntry) Errorf(format string, args ...interface{}) {
 	e.logf(ilog.ErrorLevel, format, args...)
 }
 
+func (e *implLogEntry) Panicf(format string, args ...interface{}) {
+	e.logf(ilog.PanicLevel, format, args...)
+	_ = e.logger.Sync()
+	panic(sprintf(format, args...))
+}
+
+func (e *implLogEntry) Fatalf(format string, args ...interface{}) {
+	e.logf(ilog.FatalLevel, format, args...)
+	_ = e.logger.Sync()
+	ilog.Exit(1)
+}
+
 func (e *implLogEntry) Logf(level ilog.Level, format string, args ...interface{}) {
 	e.logf(level, format, args...)
 }
 
-//nolint:cyclop,funlen
 func (e *implLogEntry) logf(level ilog.Level, format string, args ...interface{}) {
-	if level < e.logger.level {
+	if level < e.logger.Level() {
 		return
 	}
-	switch level { //nolint:exhaustive
-	case ilog.InfoLevel:
-		c := e.logger.zerologLogger.With()
-		for i := range e.zCtxs {
-			c = e.zCtxs[i](c)
-		}
-		zl := c.Logger()
-		e := zl.Info()
-		if len(args) > 0 {
-			e.Msgf(format, args...)
-			return
-		}
-		e.Msg(format)
+	c := e.logger.zerologLogger.With()
+	if len(e.logger.name) > 0 {
+		c = c.Str(nameKey, e.logger.name)
+	}
+	c = e.withFields(c)
+	if len(e.groups) > 0 {
+		c = c.Object(e.groups[0].name, groupMarshaler(e.groups))
+	}
+	zl := c.Logger()
+	var event *zerolog.Event
+	// NOTE: a level between the predefined levels is logged at the nearest lower level of zerolog.
+	switch {
+	case level >= ilog.FatalLevel:
+		// NOTE: unlike zl.Fatal, WithLevel does not exit. Fatalf exits after logging.
+		event = zl.WithLevel(zerolog.FatalLevel)
+	case level >= ilog.PanicLevel:
+		// NOTE: unlike zl.Panic, WithLevel does not panic. Panicf panics after logging.
+		event = zl.WithLevel(zerolog.PanicLevel)
+	case level >= ilog.ErrorLevel:
+		event = zl.Error()
+	case level >= ilog.WarnLevel:
+		event = zl.Warn()
+	case level >= ilog.InfoLevel:
+		event = zl.Info()
+	case level >= ilog.DebugLevel:
+		event = zl.Debug()
+	default:
+		event = zl.Trace()
+	}
+	if event == nil {
+		// NOTE: the level or the sampler of zerolog filtered the event, so it is not written and the hooks are not called.
 		return
-	case ilog.WarnLevel:
-		c := e.logger.zerologLogger.With()
-		for i := range e.zCtxs {
-			c = e.zCtxs[i](c)
-		}
-		zl := c.Logger()
-		e := zl.Warn()
-		if len(args) > 0 {
-			e.Msgf(format, args...)
-			return
-		}
-		e.Msg(format)
+	}
+	msg := sprintf(format, args...)
+	event.Msg(msg)
+
+	if len(e.logger.hooks) > 0 {
+		e.runHooks(level, msg)
+	}
+}
+
+// runHooks calls the hooks with the log entry. The fields are encoded by a zerolog.Logger without the level, timestamp and message, and decoded into a map.
+func (e *implLogEntry) runHooks(level ilog.Level, message string) {
+	entry := ilog.HookEntry{
+		Level:     level,
+		Message:   message,
+		Timestamp: time.Now(),
+	}
+	// NOTE: skip runHooks, logf and the logging method, and the frames added by AddCallerSkip
+	const skip = 3
+	if _, file, line, ok := runtime.Caller(skip + e.logger.callerSkip); ok {
+		entry.CallerFile, entry.CallerLine = file, line
+	}
+
+	buf := bytes.NewBuffer(nil)
+	c := zerolog.New(buf).With()
+	if len(e.logger.name) > 0 {
+		c = c.Str(nameKey, e.logger.name)
+	}
+	c = withFields(withFields(c, e.logger.fields), e.fields)
+	if len(e.groups) > 0 {
+		c = c.Object(e.groups[0].name, groupMarshaler(e.groups))
+	}
+	zl := c.Logger()
+	zl.Log().Send()
+	if buf.Len() == 0 {
+		// NOTE: zerolog.GlobalLevel is disabled.
 		return
-	case ilog.ErrorLevel:
-		c := e.logger.zerologLogger.With()
-		for i := range e.zCtxs {
-			c = e.zCtxs[i](c)
-		}
-		zl := c.Logger()
-		e := zl.Error()
-		if len(args) > 0 {
-			e.Msgf(format, args...)
-			return
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry.Fields); err != nil {
+		ilog.Global().Errorf("ilog: json.Unmarshal: fields=%s: %v", buf.Bytes(), err)
+	}
+
+	for _, hook := range e.logger.hooks {
+		hook.Run(entry)
+	}
+}
+
+// encoder is ilog.ObjectEncoder for zerolog.Context or zerolog.Event.
+type encoder interface {
+	ilog.ObjectEncoder
+	// addErr adds the error with zerolog.ErrorFieldName in the same way as Err of zerolog.
+	addErr(err error)
+}
+
+// groupMarshaler is zerolog.LogObjectMarshaler that adds the fields of groups[0] and the deeper groups.
+type groupMarshaler []group
+
+func (groups groupMarshaler) MarshalZerologObject(e *zerolog.Event) {
+	enc := eventEncoder{e: e}
+	for _, f := range groups[0].fields {
+		f(enc)
+	}
+	if len(groups) > 1 {
+		e.Object(groups[1].name, groups[1:])
+	}
+}
+
+// objectMarshaler is zerolog.LogObjectMarshaler that adds the fields of ilog.ObjectMarshaler.
+type objectMarshaler struct {
+	marshaler ilog.ObjectMarshaler
+	err       *error
+}
+
+// MarshalZerologObject sets the panic of the marshaler to m.err as an error, such as the one caused by a typed nil marshaler, since zerolog does not recover it.
+func (m objectMarshaler) MarshalZerologObject(e *zerolog.Event) {
+	defer func() {
+		if p := recover(); p != nil {
+			*m.err = fmt.Errorf("ilog: MarshalLogObject panicked: %v", p) //nolint:goerr113
 		}
-		e.Msg(format)
+	}()
+	*m.err = m.marshaler.MarshalLogObject(eventEncoder{e: e})
+}
+
+type contextEncoder struct {
+	c zerolog.Context
+}
+
+func (enc *contextEncoder) addErr(err error) {
+	enc.c = enc.c.Err(err)
+}
+
+func (enc *contextEncoder) AddAny(key string, value interface{}) {
+	enc.c = enc.c.Interface(key, value)
+}
+
+func (enc *contextEncoder) AddBool(key string, value bool) {
+	enc.c = enc.c.Bool(key, value)
+}
+
+func (enc *contextEncoder) AddBytes(key string, value []byte) {
+	enc.c = enc.c.Bytes(key, value)
+}
+
+func (enc *contextEncoder) AddDuration(key string, value time.Duration) {
+	enc.c = enc.c.Dur(key, value)
+}
+
+func (enc *contextEncoder) AddErr(key string, err error) {
+	enc.c = enc.c.AnErr(key, err)
+}
+
+func (enc *contextEncoder) AddFloat32(key string, value float32) {
+	enc.c = enc.c.Float32(key, value)
+}
+
+func (enc *contextEncoder) AddFloat64(key string, value float64) {
+	enc.c = enc.c.Float64(key, value)
+}
+
+func (enc *contextEncoder) AddInt(key string, value int) {
+	enc.c = enc.c.Int(key, value)
+}
+
+func (enc *contextEncoder) AddInt32(key string, value int32) {
+	enc.c = enc.c.Int32(key, value)
+}
+
+func (enc *contextEncoder) AddInt64(key string, value int64) {
+	enc.c = enc.c.Int64(key, value)
+}
+
+// AddObject adds the nested object. If the marshaler returns an error, the error is added with the key suffixed by "Error".
+func (enc *contextEncoder) AddObject(key string, marshaler ilog.ObjectMarshaler) {
+	if marshaler == nil {
+		enc.c = enc.c.Interface(key, nil)
 		return
-	default:
-		c := e.logger.zerologLogger.With()
-		for i := range e.zCtxs {
-			c = e.zCtxs[i](c)
-		}
-		zl := c.Logger()
-		e := zl.Debug()
-		if len(args) > 0 {
-			e.Msgf(format, args...)
-			return
+	}
+	var err error
+	enc.c = enc.c.Object(key, objectMarshaler{marshaler: marshaler, err: &err})
+	if err != nil {
+		enc.c = enc.c.AnErr(key+"Error", err)
+	}
+}
+
+func (enc *contextEncoder) AddString(key, value string) {
+	enc.c = enc.c.Str(key, value)
+}
+
+func (enc *contextEncoder) AddTime(key string, value time.Time) {
+	enc.c = enc.c.Time(key, value)
+}
+
+func (enc *contextEncoder) AddUint(key string, value uint) {
+	enc.c = enc.c.Uint(key, value)
+}
+
+func (enc *contextEncoder) AddUint32(key string, value uint32) {
+	enc.c = enc.c.Uint32(key, value)
+}
+
+func (enc *contextEncoder) AddUint64(key string, value uint64) {
+	enc.c = enc.c.Uint64(key, value)
+}
+
+// AddArray adds the array. If the marshaler returns an error, the error is added with the key suffixed by "Error".
+func (enc *contextEncoder) AddArray(key string, marshaler ilog.ArrayMarshaler) {
+	if marshaler == nil {
+		enc.c = enc.c.Interface(key, nil)
+		return
+	}
+	var err error
+	enc.c = enc.c.Array(key, arrayMarshaler{marshaler: marshaler, err: &err})
+	if err != nil {
+		enc.c = enc.c.AnErr(key+"Error", err)
+	}
+}
+
+func (enc *contextEncoder) AddBools(key string, values []bool) {
+	enc.c = enc.c.Bools(key, values)
+}
+
+func (enc *contextEncoder) AddDurations(key string, values []time.Duration) {
+	enc.c = enc.c.Durs(key, values)
+}
+
+func (enc *contextEncoder) AddErrs(key string, errs []error) {
+	enc.c = enc.c.Errs(key, errs)
+}
+
+func (enc *contextEncoder) AddFloat64s(key string, values []float64) {
+	enc.c = enc.c.Floats64(key, values)
+}
+
+func (enc *contextEncoder) AddInts(key string, values []int) {
+	enc.c = enc.c.Ints(key, values)
+}
+
+func (enc *contextEncoder) AddInt64s(key string, values []int64) {
+	enc.c = enc.c.Ints64(key, values)
+}
+
+func (enc *contextEncoder) AddStrings(key string, values []string) {
+	enc.c = enc.c.Strs(key, values)
+}
+
+func (enc *contextEncoder) AddTimes(key string, values []time.Time) {
+	enc.c = enc.c.Times(key, values)
+}
+
+type eventEncoder struct {
+	e *zerolog.Event
+}
+
+func (enc eventEncoder) addErr(err error) {
+	enc.e.Err(err)
+}
+
+func (enc eventEncoder) AddAny(key string, value interface{}) {
+	enc.e.Interface(key, value)
+}
+
+func (enc eventEncoder) AddBool(key string, value bool) {
+	enc.e.Bool(key, value)
+}
+
+func (enc eventEncoder) AddBytes(key string, value []byte) {
+	enc.e.Bytes(key, value)
+}
+
+func (enc eventEncoder) AddDuration(key string, value time.Duration) {
+	enc.e.Dur(key, value)
+}
+
+func (enc eventEncoder) AddErr(key string, err error) {
+	enc.e.AnErr(key, err)
+}
+
+func (enc eventEncoder) AddFloat32(key string, value float32) {
+	enc.e.Float32(key, value)
+}
+
+func (enc eventEncoder) AddFloat64(key string, value float64) {
+	enc.e.Float64(key, value)
+}
+
+func (enc eventEncoder) AddInt(key string, value int) {
+	enc.e.Int(key, value)
+}
+
+func (enc eventEncoder) AddInt32(key string, value int32) {
+	enc.e.Int32(key, value)
+}
+
+func (enc eventEncoder) AddInt64(key string, value int64) {
+	enc.e.Int64(key, value)
+}
+
+// AddObject adds the nested object. If the marshaler returns an error, the error is added with the key suffixed by "Error".
+func (enc eventEncoder) AddObject(key string, marshaler ilog.ObjectMarshaler) {
+	if marshaler == nil {
+		enc.e.Interface(key, nil)
+		return
+	}
+	var err error
+	enc.e.Object(key, objectMarshaler{marshaler: marshaler, err: &err})
+	if err != nil {
+		enc.e.AnErr(key+"Error", err)
+	}
+}
+
+func (enc eventEncoder) AddString(key, value string) {
+	enc.e.Str(key, value)
+}
+
+func (enc eventEncoder) AddTime(key string, value time.Time) {
+	enc.e.Time(key, value)
+}
+
+func (enc eventEncoder) AddUint(key string, value uint) {
+	enc.e.Uint(key, value)
+}
+
+func (enc eventEncoder) AddUint32(key string, value uint32) {
+	enc.e.Uint32(key, value)
+}
+
+func (enc eventEncoder) AddUint64(key string, value uint64) {
+	enc.e.Uint64(key, value)
+}
+
+// AddArray adds the array. If the marshaler returns an error, the error is added with the key suffixed by "Error".
+func (enc eventEncoder) AddArray(key string, marshaler ilog.ArrayMarshaler) {
+	if marshaler == nil {
+		enc.e.Interface(key, nil)
+		return
+	}
+	var err error
+	enc.e.Array(key, arrayMarshaler{marshaler: marshaler, err: &err})
+	if err != nil {
+		enc.e.AnErr(key+"Error", err)
+	}
+}
+
+func (enc eventEncoder) AddBools(key string, values []bool) {
+	enc.e.Bools(key, values)
+}
+
+func (enc eventEncoder) AddDurations(key string, values []time.Duration) {
+	enc.e.Durs(key, values)
+}
+
+func (enc eventEncoder) AddErrs(key string, errs []error) {
+	enc.e.Errs(key, errs)
+}
+
+func (enc eventEncoder) AddFloat64s(key string, values []float64) {
+	enc.e.Floats64(key, values)
+}
+
+func (enc eventEncoder) AddInts(key string, values []int) {
+	enc.e.Ints(key, values)
+}
+
+func (enc eventEncoder) AddInt64s(key string, values []int64) {
+	enc.e.Ints64(key, values)
+}
+
+func (enc eventEncoder) AddStrings(key string, values []string) {
+	enc.e.Strs(key, values)
+}
+
+func (enc eventEncoder) AddTimes(key string, values []time.Time) {
+	enc.e.Times(key, values)
+}
+
+// arrayMarshaler is zerolog.LogArrayMarshaler that appends the elements of ilog.ArrayMarshaler.
+type arrayMarshaler struct {
+	marshaler ilog.ArrayMarshaler
+	err       *error
+}
+
+// MarshalZerologArray sets the panic of the marshaler to m.err as an error, such as the one caused by a typed nil marshaler, since zerolog does not recover it.
+func (m arrayMarshaler) MarshalZerologArray(a *zerolog.Array) {
+	defer func() {
+		if p := recover(); p != nil {
+			*m.err = fmt.Errorf("ilog: MarshalLogArray panicked: %v", p) //nolint:goerr113
 		}
-		e.Msg(format)
+	}()
+	*m.err = m.marshaler.MarshalLogArray(arrayEncoder{a: a})
+}
+
+// arrayEncoder is ilog.ArrayEncoder that appends elements to zerolog.Array.
+type arrayEncoder struct {
+	a *zerolog.Array
+}
+
+func (enc arrayEncoder) AppendAny(value interface{}) {
+	enc.a.Interface(value)
+}
+
+// AppendArray appends the nested array.
+// Since zerolog.Array cannot contain an array, the nested array is encoded by a logger for the nested array and appended as raw JSON.
+func (enc arrayEncoder) AppendArray(marshaler ilog.ArrayMarshaler) {
+	if marshaler == nil {
+		enc.a.Interface(nil)
+		return
+	}
+	const key = "a"
+	var err error
+	buf := bytes.NewBuffer(nil)
+	l := zerolog.New(buf)
+	l.Log().Array(key, arrayMarshaler{marshaler: marshaler, err: &err}).Send()
+	// NOTE: buf == {"a":[...]}\n
+	b := bytes.TrimSpace(buf.Bytes())
+	enc.a.RawJSON(b[len(`{"`+key+`":`) : len(b)-len(`}`)])
+}
+
+func (enc arrayEncoder) AppendBool(value bool) {
+	enc.a.Bool(value)
+}
+
+func (enc arrayEncoder) AppendBytes(value []byte) {
+	enc.a.Bytes(value)
+}
+
+func (enc arrayEncoder) AppendDuration(value time.Duration) {
+	enc.a.Dur(value)
+}
+
+func (enc arrayEncoder) AppendErr(err error) {
+	enc.a.Err(err)
+}
+
+func (enc arrayEncoder) AppendFloat32(value float32) {
+	enc.a.Float32(value)
+}
+
+func (enc arrayEncoder) AppendFloat64(value float64) {
+	enc.a.Float64(value)
+}
+
+func (enc arrayEncoder) AppendInt(value int) {
+	enc.a.Int(value)
+}
+
+func (enc arrayEncoder) AppendInt32(value int32) {
+	enc.a.Int32(value)
+}
+
+func (enc arrayEncoder) AppendInt64(value int64) {
+	enc.a.Int64(value)
+}
+
+func (enc arrayEncoder) AppendObject(marshaler ilog.ObjectMarshaler) {
+	if marshaler == nil {
+		enc.a.Interface(nil)
 		return
 	}
+	var err error
+	enc.a.Object(objectMarshaler{marshaler: marshaler, err: &err})
+}
+
+func (enc arrayEncoder) AppendString(value string) {
+	enc.a.Str(value)
+}
+
+func (enc arrayEncoder) AppendTime(value time.Time) {
+	enc.a.Time(value)
+}
+
+func (enc arrayEncoder) AppendUint(value uint) {
+	enc.a.Uint(value)
+}
+
+func (enc arrayEncoder) AppendUint32(value uint32) {
+	enc.a.Uint32(value)
+}
+
+func (enc arrayEncoder) AppendUint64(value uint64) {
+	enc.a.Uint64(value)
+}
+
+// stacktrace returns the stack trace of the current goroutine in the same format as the default implementation of ilog.
+func stacktrace(skip int) string {
+	const depth = 64
+	pc := make([]uintptr, depth)
+	n := runtime.Callers(skip, pc)
+	for n == len(pc) {
+		pc = make([]uintptr, len(pc)*2) //nolint:gomnd
+		n = runtime.Callers(skip, pc)
+	}
+	if n == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	frames := runtime.CallersFrames(pc[:n])
+	for {
+		frame, more := frames.Next()
+		b.WriteString(frame.Function)
+		b.WriteString("\n\t")
+		b.WriteString(frame.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(frame.Line))
+		if !more {
+			break
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// joinName returns the name of a child logger, e.g. "db.pool" for "db" and "pool".
+func joinName(parent, name string) string {
+	switch {
+	case parent == "":
+		return name
+	case name == "":
+		return parent
+	default:
+		return parent + "." + name
+	}
+}
+
+// lookupLevelVar returns the LevelVar configured for the name in the registry, or current if it is not configured.
+func lookupLevelVar(r *ilog.LevelRegistry, name string, current *ilog.LevelVar) *ilog.LevelVar {
+	if r == nil {
+		return current
+	}
+	if v := r.LevelVar(name); v != nil {
+		return v
+	}
+	return current
+}
+
+// nameKey is the key of the logger name field set by Named.
+const nameKey = "logger"
+
+// sprintf returns format as it is if args is empty, otherwise fmt.Sprintf(format, args...).
+func sprintf(format string, args ...interface{}) string {
+	if len(args) > 0 {
+		return fmt.Sprintf(format, args...)
+	}
+	return format
 }
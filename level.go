@@ -0,0 +1,97 @@
+package ilog
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//nolint:gochecknoglobals
+var (
+	// _levelNames is the names of the predefined levels and the registered levels. It is replaced instead of modified by RegisterLevel, so it can be read without lock after levelNames returns.
+	_levelNames   = copyLevels(defaultLevels)
+	_levelNamesMu sync.RWMutex
+)
+
+// RegisterLevel registers the name of a custom level such as NOTICE, which is used by Level.String, ParseLevel and the default implementation built after registering.
+// If the level already has a name, the name is replaced. It is intended to be called in init or at the beginning of main.
+//
+//	const NoticeLevel ilog.Level = 4
+//
+//	func init() {
+//		ilog.RegisterLevel(NoticeLevel, "NOTICE")
+//	}
+func RegisterLevel(level Level, name string) {
+	_levelNamesMu.Lock()
+	defer _levelNamesMu.Unlock()
+
+	names := copyLevels(_levelNames)
+	names[level] = name
+	_levelNames = names
+}
+
+// levelNames returns the names of the predefined levels and the registered levels. It must not be modified.
+func levelNames() map[Level]string {
+	_levelNamesMu.RLock()
+	defer _levelNamesMu.RUnlock()
+	return _levelNames
+}
+
+// ParseLevel converts a level name such as "debug", a name with a difference such as "INFO+4", or a number such as "-8" to Level.
+// The name is case-insensitive, and the names registered by RegisterLevel are also accepted.
+func ParseLevel(s string) (Level, error) {
+	names := levelNames()
+	if level, ok := findLevel(names, s); ok {
+		return level, nil
+	}
+	const base, bitSize = 10, 8
+	if i, err := strconv.ParseInt(s, base, bitSize); err == nil {
+		return Level(i), nil
+	}
+	if i := strings.LastIndexAny(s, "+-"); i > 0 {
+		level, ok := findLevel(names, s[:i])
+		diff, err := strconv.Atoi(s[i:])
+		if sum := int(level) + diff; ok && err == nil && sum >= math.MinInt8 && sum <= math.MaxInt8 {
+			return Level(sum), nil
+		}
+	}
+
+	return 0, fmt.Errorf("ilog: invalid level: %s", s) //nolint:goerr113
+}
+
+func findLevel(names map[Level]string, s string) (Level, bool) {
+	for level, name := range names {
+		if strings.EqualFold(name, s) {
+			return level, true
+		}
+	}
+	return 0, false
+}
+
+// String returns the name of the level such as "DEBUG".
+// If the level has no name, it returns the name of the nearest lower level with the difference such as "INFO+4", like slog.Level.
+func (l Level) String() string {
+	return levelName(levelNames(), l)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l Level) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. It accepts the same strings as ParseLevel.
+func (l *Level) UnmarshalText(text []byte) error {
+	level, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = level
+	return nil
+}
+
+// Set implements flag.Value, so that the level can be set by a command-line flag such as `flag.Var(&level, "log-level", "...")`.
+func (l *Level) Set(s string) error {
+	return l.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,147 @@
+package ilog
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// LevelRegistry is the registry of levels for the loggers named by Logger.Named.
+// A pattern matches a logger name as follows, and the most specific matching pattern is used:
+//
+//	"db"    matches "db" and its descendants such as "db.pool".
+//	"db.*"  matches only the descendants of "db".
+//	"*"     matches all the names.
+//
+// A pattern equal to the name is the most specific. The other patterns are ranked by the length of the name without ".*" or "*",
+// and "db.*" is more specific than "db" for the descendants of "db".
+//
+// The level of a pattern is resolved when Named is called, so checking it in logf costs only an atomic load.
+// Since the level of a pattern is a LevelVar, Set changes the level of the loggers that already use the pattern, but a new pattern is used only by the loggers named after it is added.
+type LevelRegistry struct {
+	mu       sync.RWMutex
+	patterns []levelPattern
+}
+
+type levelPattern struct {
+	pattern string
+	// prefix is the name that the pattern matches, without ".*" or "*".
+	prefix string
+	// self is whether the pattern matches the prefix itself.
+	self     bool
+	levelVar *LevelVar
+}
+
+// NewLevelRegistry returns a new empty LevelRegistry.
+func NewLevelRegistry() *LevelRegistry {
+	return &LevelRegistry{}
+}
+
+// ParseLevelRegistry returns a new LevelRegistry from comma-separated `pattern=level` pairs such as "db.*=DEBUG,http=WARN".
+// It is intended to parse a string such as an environment variable. The levels are the names such as "DEBUG" or the numbers such as "-4".
+func ParseLevelRegistry(s string) (*LevelRegistry, error) {
+	r := NewLevelRegistry()
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		i := strings.LastIndex(pair, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("ilog: invalid level registry: %q: missing '='", pair) //nolint:goerr113
+		}
+		pattern, levelString := strings.TrimSpace(pair[:i]), strings.TrimSpace(pair[i+1:])
+		if pattern == "" {
+			return nil, fmt.Errorf("ilog: invalid level registry: %q: empty pattern", pair) //nolint:goerr113
+		}
+		level, err := ParseLevel(levelString)
+		if err != nil {
+			return nil, fmt.Errorf("ilog: invalid level registry: %q: %w", pair, err)
+		}
+		r.Set(pattern, level)
+	}
+
+	return r, nil
+}
+
+// Set sets the level of the pattern. If the pattern already exists, its LevelVar is changed.
+func (r *LevelRegistry) Set(pattern string, level Level) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, p := range r.patterns {
+		if p.pattern == pattern {
+			p.levelVar.Set(level)
+			return
+		}
+	}
+
+	p := levelPattern{pattern: pattern, prefix: pattern, self: true, levelVar: NewLevelVar(level)}
+	switch {
+	case pattern == "*":
+		p.prefix, p.self = "", false
+	case strings.HasSuffix(pattern, ".*"):
+		p.prefix, p.self = strings.TrimSuffix(pattern, ".*"), false
+	}
+	r.patterns = append(r.patterns, p)
+}
+
+// LevelVar returns the LevelVar of the most specific pattern that matches the logger name, or nil if no pattern matches.
+// It is intended for the implementations of Logger.Named.
+func (r *LevelRegistry) LevelVar(name string) *LevelVar {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var (
+		found *LevelVar
+		rank  = -1
+	)
+	for _, p := range r.patterns {
+		if !p.match(name) {
+			continue
+		}
+		if pr := p.rank(name); pr > rank {
+			found, rank = p.levelVar, pr
+		}
+	}
+
+	return found
+}
+
+func (p levelPattern) match(name string) bool {
+	if p.prefix == "" {
+		return true
+	}
+	if name == p.prefix {
+		return p.self
+	}
+
+	return strings.HasPrefix(name, p.prefix) && name[len(p.prefix)] == '.'
+}
+
+// rank returns the specificity of the pattern for the name that it matches.
+func (p levelPattern) rank(name string) int {
+	if p.self && name == p.prefix {
+		return math.MaxInt32
+	}
+	// NOTE: for the same prefix, "db.*" ranks above "db", since it is written only for the descendants.
+	rank := 2 * len(p.prefix) //nolint:gomnd
+	if !p.self {
+		rank++
+	}
+
+	return rank
+}
+
+// joinName returns the name of a child logger, e.g. "db.pool" for "db" and "pool".
+func joinName(parent, name string) string {
+	switch {
+	case parent == "":
+		return name
+	case name == "":
+		return parent
+	default:
+		return parent + "." + name
+	}
+}
@@ -0,0 +1,126 @@
+package ilog //nolint:testpackage
+
+import (
+	"testing"
+)
+
+func TestParseLevelRegistry(t *testing.T) {
+	t.Parallel()
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+		r, err := ParseLevelRegistry(" db.*=DEBUG, http=warn ,,*=8,db.pool=-4,http=ERROR")
+		if err != nil {
+			t.Fatalf("❌: err: %v", err)
+		}
+
+		for _, tt := range []struct {
+			name     string
+			expected Level
+		}{
+			{"db.conn", DebugLevel},
+			{"db.pool", -4},
+			{"db.pool.conn", -4},
+			{"db", WarnLevel},
+			{"http", ErrorLevel},
+			{"http.client", ErrorLevel},
+			{"httpx", WarnLevel},
+			{"", WarnLevel},
+		} {
+			v := r.LevelVar(tt.name)
+			if v == nil {
+				t.Errorf("❌: %s: v == nil", tt.name)
+				continue
+			}
+			if actual := v.Level(); tt.expected != actual {
+				t.Errorf("❌: %s: expected(%d) != actual(%d)", tt.name, tt.expected, actual)
+			}
+		}
+	})
+
+	t.Run("success,noMatch", func(t *testing.T) {
+		t.Parallel()
+		r, err := ParseLevelRegistry("db.*=DEBUG")
+		if err != nil {
+			t.Fatalf("❌: err: %v", err)
+		}
+		for _, name := range []string{"db", "dbx.pool", "http"} {
+			if v := r.LevelVar(name); v != nil {
+				t.Errorf("❌: %s: v(%d) != nil", name, v.Level())
+			}
+		}
+	})
+
+	t.Run("success,specificity", func(t *testing.T) {
+		t.Parallel()
+		for _, tt := range []struct {
+			s        string
+			name     string
+			expected Level
+		}{
+			{"db.*=DEBUG,db.x=WARN", "db.x", WarnLevel},
+			{"db.x=WARN,db.*=DEBUG", "db.x", WarnLevel},
+			{"db.*=DEBUG,db.x=WARN", "db.y", DebugLevel},
+			{"*=DEBUG,a=WARN", "a", WarnLevel},
+			{"a=WARN,*=DEBUG", "a", WarnLevel},
+			{"*=DEBUG,a=WARN", "a.b", WarnLevel},
+			{"*=DEBUG,a=WARN", "b", DebugLevel},
+			{"a=WARN,a.*=DEBUG", "a.b", DebugLevel},
+			{"a.*=DEBUG,a=WARN", "a.b", DebugLevel},
+			{"a.*=DEBUG,a=WARN", "a", WarnLevel},
+			{"a.*=DEBUG,a.b=ERROR", "a.b.c", ErrorLevel},
+			{"a.b.*=ERROR,a.bc=DEBUG", "a.b.c", ErrorLevel},
+		} {
+			r, err := ParseLevelRegistry(tt.s)
+			if err != nil {
+				t.Fatalf("❌: err: %v", err)
+			}
+			v := r.LevelVar(tt.name)
+			if v == nil {
+				t.Errorf("❌: %s: %s: v == nil", tt.s, tt.name)
+				continue
+			}
+			if actual := v.Level(); tt.expected != actual {
+				t.Errorf("❌: %s: %s: expected(%d) != actual(%d)", tt.s, tt.name, tt.expected, actual)
+			}
+		}
+	})
+
+	t.Run("failure,", func(t *testing.T) {
+		t.Parallel()
+		for _, tt := range []struct {
+			s        string
+			expected string
+		}{
+			{"db", `ilog: invalid level registry: "db": missing '='`},
+			{"=DEBUG", `ilog: invalid level registry: "=DEBUG": empty pattern`},
+			{"db=VERBOSE", `ilog: invalid level registry: "db=VERBOSE": ilog: invalid level: VERBOSE`},
+		} {
+			_, err := ParseLevelRegistry(tt.s)
+			if err == nil {
+				t.Errorf("❌: %s: err == nil", tt.s)
+				continue
+			}
+			if actual := err.Error(); tt.expected != actual {
+				t.Errorf("❌: expected(%q) != actual(%q)", tt.expected, actual)
+			}
+		}
+	})
+}
+
+func TestLevelRegistry_Set(t *testing.T) {
+	t.Parallel()
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+		r := NewLevelRegistry()
+		r.Set("db", WarnLevel)
+		v := r.LevelVar("db.pool")
+
+		r.Set("db", DebugLevel)
+		if expected, actual := DebugLevel, v.Level(); expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+		if expected, actual := v, r.LevelVar("db"); expected != actual {
+			t.Errorf("❌: expected(%p) != actual(%p)", expected, actual)
+		}
+	})
+}
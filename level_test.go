@@ -0,0 +1,155 @@
+package ilog //nolint:testpackage
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"testing"
+)
+
+func TestParseLevel(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		s        string
+		expected Level
+	}{
+		{"TRACE", TraceLevel},
+		{"debug", DebugLevel},
+		{"Info", InfoLevel},
+		{"WARN", WarnLevel},
+		{"error", ErrorLevel},
+		{"panic", PanicLevel},
+		{"FATAL", FatalLevel},
+		{"4", 4},
+		{"-128", -128},
+		{"INFO+4", 4},
+		{"debug-4", -12},
+		{"FATAL+95", 127},
+	} {
+		actual, err := ParseLevel(tt.s)
+		if err != nil {
+			t.Errorf("❌: %s: err: %v", tt.s, err)
+			continue
+		}
+		if tt.expected != actual {
+			t.Errorf("❌: %s: expected(%d) != actual(%d)", tt.s, tt.expected, actual)
+		}
+	}
+
+	for _, s := range []string{"", "verbose", "128", "1.5", "VERBOSE+1", "FATAL+96", "INFO+x"} {
+		if _, err := ParseLevel(s); err == nil {
+			t.Errorf("❌: %s: err == nil", s)
+		}
+	}
+}
+
+func TestLevel_String(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		level    Level
+		expected string
+	}{
+		{TraceLevel, "TRACE"},
+		{DebugLevel, "DEBUG"},
+		{InfoLevel, "INFO"},
+		{WarnLevel, "WARN"},
+		{ErrorLevel, "ERROR"},
+		{PanicLevel, "PANIC"},
+		{FatalLevel, "FATAL"},
+		{4, "INFO+4"},
+		{-20, "TRACE-4"},
+		{127, "FATAL+95"},
+	} {
+		if actual := tt.level.String(); tt.expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", tt.expected, actual)
+		}
+	}
+}
+
+func TestLevel_MarshalText(t *testing.T) {
+	t.Parallel()
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+		type config struct {
+			Level Level `json:"level"`
+		}
+
+		b, err := json.Marshal(config{Level: WarnLevel})
+		if err != nil {
+			t.Fatalf("❌: err: %v", err)
+		}
+		if expected, actual := `{"level":"WARN"}`, string(b); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+
+		var c config
+		if err := json.Unmarshal([]byte(`{"level":"trace"}`), &c); err != nil {
+			t.Fatalf("❌: err: %v", err)
+		}
+		if expected, actual := TraceLevel, c.Level; expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+	})
+
+	t.Run("failure,", func(t *testing.T) {
+		t.Parallel()
+		level := InfoLevel
+		if err := level.UnmarshalText([]byte("verbose")); err == nil {
+			t.Errorf("❌: err == nil")
+		}
+		if expected, actual := InfoLevel, level; expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+	})
+}
+
+func TestLevel_Set(t *testing.T) {
+	t.Parallel()
+	level := InfoLevel
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&level, "log-level", "log level")
+
+	if err := fs.Parse([]string{"-log-level", "error"}); err != nil {
+		t.Fatalf("❌: err: %v", err)
+	}
+	if expected, actual := ErrorLevel, level; expected != actual {
+		t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+	}
+	if expected, actual := "ERROR", fs.Lookup("log-level").Value.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
+
+//nolint:paralleltest
+func TestRegisterLevel(t *testing.T) {
+	backup := levelNames()
+	defer func() {
+		_levelNamesMu.Lock()
+		_levelNames = backup
+		_levelNamesMu.Unlock()
+	}()
+
+	const noticeLevel, criticalLevel Level = 4, 20
+	RegisterLevel(noticeLevel, "NOTICE")
+	RegisterLevel(criticalLevel, "CRITICAL")
+
+	if expected, actual := "NOTICE", noticeLevel.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+	if expected, actual := "CRITICAL+2", (criticalLevel + 2).String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+	if level, err := ParseLevel("critical"); err != nil || level != criticalLevel {
+		t.Errorf("❌: level(%d), err(%v)", level, err)
+	}
+	if _, ok := backup[noticeLevel]; ok {
+		t.Errorf("❌: the names before registering are modified")
+	}
+
+	buf := bytes.NewBuffer(nil)
+	l := NewBuilder(DebugLevel, buf).SetTimestampKey("").SetCallerKey("").Build()
+	l.Logf(noticeLevel, "Logf")
+	if expected, actual := `{"severity":"NOTICE","message":"Logf"}`+"\n", buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
@@ -0,0 +1,89 @@
+package ilog
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sync/atomic"
+)
+
+// LevelVar is a Level variable that can be changed at runtime and shared by loggers.
+// It is safe for concurrent use, and the loggers that share it read it every time they log.
+type LevelVar struct {
+	level int32
+}
+
+// NewLevelVar returns a new LevelVar that has the specified level.
+func NewLevelVar(level Level) *LevelVar {
+	v := &LevelVar{}
+	v.Set(level)
+	return v
+}
+
+// Level returns the current level.
+func (v *LevelVar) Level() Level {
+	return Level(atomic.LoadInt32(&v.level))
+}
+
+// Set changes the level.
+func (v *LevelVar) Set(level Level) {
+	atomic.StoreInt32(&v.level, int32(level))
+}
+
+type levelVarPayload struct {
+	Level interface{} `json:"level"`
+}
+
+type levelVarErrorPayload struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP implements http.Handler to get and change the level as JSON.
+//
+//	GET: responds the current level such as `{"level":"DEBUG"}`, or `{"level":"DEBUG+4"}` if the level has no name.
+//	PUT: changes the level with a request body such as `{"level":"DEBUG"}`, `{"level":"DEBUG+4"}` or `{"level":-8}`, and responds the new level.
+func (v *LevelVar) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		var payload levelVarPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			writeLevelVarError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+			return
+		}
+		level, err := levelFromJSON(payload.Level)
+		if err != nil {
+			writeLevelVarError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		v.Set(level)
+	default:
+		w.Header().Set("Allow", http.MethodGet+", "+http.MethodPut)
+		writeLevelVarError(w, http.StatusMethodNotAllowed, "method not allowed: "+r.Method)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(levelVarPayload{Level: v.Level()})
+}
+
+func writeLevelVarError(w http.ResponseWriter, code int, message string) {
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(levelVarErrorPayload{Error: message})
+}
+
+// levelFromJSON converts a level name such as "debug" or a number decoded from JSON to Level.
+func levelFromJSON(v interface{}) (Level, error) {
+	switch v := v.(type) {
+	case string:
+		return ParseLevel(v)
+	case float64:
+		if v >= math.MinInt8 && v <= math.MaxInt8 && v == math.Trunc(v) {
+			return Level(v), nil
+		}
+	}
+
+	return 0, fmt.Errorf("ilog: invalid level: %v", v) //nolint:goerr113
+}
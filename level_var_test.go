@@ -0,0 +1,48 @@
+package ilog //nolint:testpackage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLevelVar_ServeHTTP(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name         string
+		method       string
+		body         string
+		expectedCode int
+		expectedBody string
+		expected     Level
+	}{
+		{"success,GET", http.MethodGet, "", http.StatusOK, `{"level":"INFO"}`, InfoLevel},
+		{"success,PUT,name", http.MethodPut, `{"level":"debug"}`, http.StatusOK, `{"level":"DEBUG"}`, DebugLevel},
+		{"success,PUT,number", http.MethodPut, `{"level":-4}`, http.StatusOK, `{"level":"DEBUG+4"}`, -4},
+		{"success,PUT,interpolated", http.MethodPut, `{"level":"info+4"}`, http.StatusOK, `{"level":"INFO+4"}`, 4},
+		{"success,PUT,numberString", http.MethodPut, `{"level":"8"}`, http.StatusOK, `{"level":"WARN"}`, WarnLevel},
+		{"failure,PUT,invalidBody", http.MethodPut, `{`, http.StatusBadRequest, `{"error":"invalid request body: unexpected EOF"}`, InfoLevel},
+		{"failure,PUT,invalidLevel", http.MethodPut, `{"level":"verbose"}`, http.StatusBadRequest, `{"error":"ilog: invalid level: verbose"}`, InfoLevel},
+		{"failure,PUT,outOfRange", http.MethodPut, `{"level":128}`, http.StatusBadRequest, `{"error":"ilog: invalid level: 128"}`, InfoLevel},
+		{"failure,POST", http.MethodPost, `{"level":"debug"}`, http.StatusMethodNotAllowed, `{"error":"method not allowed: POST"}`, InfoLevel},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v := NewLevelVar(InfoLevel)
+			w := httptest.NewRecorder()
+			v.ServeHTTP(w, httptest.NewRequest(tt.method, "/level", strings.NewReader(tt.body)))
+
+			if actual := w.Code; tt.expectedCode != actual {
+				t.Errorf("❌: expected(%d) != actual(%d)", tt.expectedCode, actual)
+			}
+			if actual := w.Body.String(); tt.expectedBody+"\n" != actual {
+				t.Errorf("❌: expected(%q) != actual(%q)", tt.expectedBody+"\n", actual)
+			}
+			if actual := v.Level(); tt.expected != actual {
+				t.Errorf("❌: expected(%d) != actual(%d)", tt.expected, actual)
+			}
+		})
+	}
+}
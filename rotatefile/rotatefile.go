@@ -0,0 +1,436 @@
+// Package rotatefile provides io.Writer which writes to a file and rotates it by size or time.
+package rotatefile
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrClosed is the error returned when writing to a closed Writer.
+var ErrClosed = errors.New("rotatefile: writer closed")
+
+const (
+	// backupTimeFormat is the format of the time in the names of backup files, e.g. "app-2023-08-13T04-38-39.123.log".
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+	compressSuffix   = ".gz"
+	fileMode         = 0o644
+	dirMode          = 0o755
+)
+
+// Option is the option of New.
+type Option func(w *Writer)
+
+// WithMaxSize sets the maximum size in bytes of the file. If writing an entry makes the file larger than size, the file is rotated before writing.
+// Default is 0, so the file is not rotated by size.
+func WithMaxSize(size int64) Option {
+	return func(w *Writer) {
+		w.maxSize = size
+	}
+}
+
+// WithRotationInterval sets the interval of time-based rotation. The file is rotated when the first entry in a new interval is written.
+// The intervals are aligned to the zero time in UTC like time.Time.Truncate, e.g. 24*time.Hour rotates the file at midnight UTC.
+// Default is 0, so the file is not rotated by time.
+func WithRotationInterval(interval time.Duration) Option {
+	return func(w *Writer) {
+		w.interval = interval
+	}
+}
+
+// WithMaxBackups sets the maximum number of backup files to keep.
+// Default is 0, so all backup files are kept unless they are removed by WithMaxAge.
+func WithMaxBackups(n int) Option {
+	return func(w *Writer) {
+		w.maxBackups = n
+	}
+}
+
+// WithMaxAge sets the maximum age of backup files to keep. The age is determined by the time in the name of the backup file.
+// Default is 0, so backup files are not removed by age.
+func WithMaxAge(age time.Duration) Option {
+	return func(w *Writer) {
+		w.maxAge = age
+	}
+}
+
+// WithCompress sets whether to compress backup files with gzip.
+// Default is false.
+func WithCompress(compress bool) Option {
+	return func(w *Writer) {
+		w.compress = compress
+	}
+}
+
+// Writer is io.Writer that writes to a file and rotates it by size or time.
+// The rotated file is renamed to a backup file whose name has the time of the rotation in UTC, such as "app-2023-08-13T04-38-39.123.log" for "app.log".
+// Backup files are compressed and removed in a background goroutine.
+//
+// Writer is safe for concurrent use, so it can be passed to ilog.NewBuilder with or without UseSyncWriter.
+type Writer struct {
+	filename   string
+	maxSize    int64
+	interval   time.Duration
+	maxBackups int
+	maxAge     time.Duration
+	compress   bool
+	now        func() time.Time
+
+	mu sync.Mutex
+	// file is nil if the file failed to be opened by the rotation or Reopen, and it is opened again by the next call.
+	file   *os.File
+	closed bool
+	size   int64
+	// boundary is the start of the interval in which the file was opened.
+	boundary time.Time
+
+	millCh   chan struct{}
+	millDone chan struct{}
+}
+
+// New returns a new Writer that writes to filename. If the file exists, entries are appended to it.
+// Close must be called to close the file and stop the background goroutine.
+func New(filename string, opts ...Option) (*Writer, error) {
+	w := &Writer{
+		filename: filename,
+		now:      time.Now,
+		millCh:   make(chan struct{}, 1),
+		millDone: make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), dirMode); err != nil {
+		return nil, fmt.Errorf("os.MkdirAll: %w", err)
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+
+	go w.mill()
+	// NOTE: clean up the backup files left by the previous process.
+	w.millCh <- struct{}{}
+
+	return w, nil
+}
+
+// Write writes p to the file. If p does not fit in the file or a new interval has started, the file is rotated before writing.
+// If the previous rotation failed to open the file, Write opens it again.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.ensureOpen(); err != nil {
+		return 0, err
+	}
+
+	if w.shouldRotate(len(p)) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	if err != nil {
+		return n, fmt.Errorf("w.file.Write: %w", err)
+	}
+
+	return n, nil
+}
+
+// Rotate rotates the file regardless of its size and time.
+func (w *Writer) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.ensureOpen(); err != nil {
+		return err
+	}
+
+	return w.rotate()
+}
+
+// Reopen closes and reopens the file without rotating it.
+// Call it when the file was moved by another program such as logrotate, typically on SIGHUP.
+func (w *Writer) Reopen() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.closed {
+		return ErrClosed
+	}
+	if w.file != nil {
+		err := w.file.Close()
+		w.file = nil
+		if err != nil {
+			return fmt.Errorf("w.file.Close: %w", err)
+		}
+	}
+
+	return w.open()
+}
+
+// Sync commits the contents of the file to stable storage.
+func (w *Writer) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.ensureOpen(); err != nil {
+		return err
+	}
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("w.file.Sync: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the file, and waits for the background goroutine to finish compressing and removing backup files.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return nil
+	}
+	var err error
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+	w.closed = true
+	close(w.millCh)
+	w.mu.Unlock()
+
+	<-w.millDone
+	if err != nil {
+		return fmt.Errorf("w.file.Close: %w", err)
+	}
+
+	return nil
+}
+
+func (w *Writer) shouldRotate(n int) bool {
+	if w.maxSize > 0 && w.size > 0 && w.size+int64(n) > w.maxSize {
+		return true
+	}
+	if w.interval > 0 && !w.now().Truncate(w.interval).Equal(w.boundary) {
+		return true
+	}
+
+	return false
+}
+
+// ensureOpen returns ErrClosed if the Writer is closed, and opens the file if it failed to be opened by the rotation or Reopen.
+func (w *Writer) ensureOpen() error {
+	if w.closed {
+		return ErrClosed
+	}
+	if w.file == nil {
+		return w.open()
+	}
+	return nil
+}
+
+func (w *Writer) open() error {
+	f, err := os.OpenFile(w.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, fileMode)
+	if err != nil {
+		return fmt.Errorf("os.OpenFile: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("f.Stat: %w", err)
+	}
+
+	w.file = f
+	w.size = info.Size()
+	w.boundary = w.now().Truncate(w.interval)
+	if w.interval > 0 && w.size > 0 {
+		// NOTE: if the file was written in a previous interval, it is rotated by the next write.
+		w.boundary = info.ModTime().Truncate(w.interval)
+	}
+
+	return nil
+}
+
+// rotate renames the file to a backup file and opens a new file.
+// If it fails, w.file may be nil, and the file is opened again by the next call.
+func (w *Writer) rotate() error {
+	err := w.file.Close()
+	w.file = nil
+	if err != nil {
+		return fmt.Errorf("w.file.Close: %w", err)
+	}
+
+	if err := os.Rename(w.filename, w.backupName(w.now())); err != nil {
+		// NOTE: keep writing to the current file.
+		_ = w.open()
+		return fmt.Errorf("os.Rename: %w", err)
+	}
+	f, err := os.OpenFile(w.filename, os.O_CREATE|os.O_WRONLY|os.O_TRUNC|os.O_APPEND, fileMode)
+	if err != nil {
+		return fmt.Errorf("os.OpenFile: %w", err)
+	}
+	w.file = f
+	w.size = 0
+	w.boundary = w.now().Truncate(w.interval)
+
+	select {
+	case w.millCh <- struct{}{}:
+	default:
+		// NOTE: the background goroutine has not processed the previous rotation yet.
+	}
+
+	return nil
+}
+
+// prefixAndExt returns the prefix and extension of the names of backup files, e.g. "app-" and ".log" for "/path/to/app.log".
+func (w *Writer) prefixAndExt() (prefix, ext string) {
+	base := filepath.Base(w.filename)
+	ext = filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "-", ext
+}
+
+func (w *Writer) backupName(t time.Time) string {
+	prefix, ext := w.prefixAndExt()
+	name := filepath.Join(filepath.Dir(w.filename), prefix+t.UTC().Format(backupTimeFormat))
+	backup := name + ext
+	for i := 1; exists(backup) || exists(backup+compressSuffix); i++ {
+		backup = fmt.Sprintf("%s-%d%s", name, i, ext)
+	}
+	return backup
+}
+
+func exists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+type backup struct {
+	name string
+	time time.Time
+}
+
+// backups returns the backup files sorted from newest to oldest.
+func (w *Writer) backups() ([]backup, error) {
+	dir := filepath.Dir(w.filename)
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("ioutil.ReadDir: %w", err)
+	}
+
+	prefix, ext := w.prefixAndExt()
+	backups := make([]backup, 0, len(infos))
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasPrefix(name, prefix) || !(strings.HasSuffix(name, ext) || strings.HasSuffix(name, ext+compressSuffix)) {
+			continue
+		}
+		ts := strings.TrimPrefix(name, prefix)
+		if len(ts) < len(backupTimeFormat) {
+			continue
+		}
+		t, err := time.Parse(backupTimeFormat, ts[:len(backupTimeFormat)])
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{name: filepath.Join(dir, name), time: t})
+	}
+
+	sort.SliceStable(backups, func(i, j int) bool {
+		if backups[i].time.Equal(backups[j].time) {
+			return backups[i].name > backups[j].name
+		}
+		return backups[i].time.After(backups[j].time)
+	})
+
+	return backups, nil
+}
+
+func (w *Writer) mill() {
+	defer close(w.millDone)
+	for range w.millCh {
+		// NOTE: the errors are ignored because there is no logger to report them to. The next rotation retries.
+		_ = w.millRun()
+	}
+}
+
+func (w *Writer) millRun() error {
+	backups, err := w.backups()
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	cutoff := w.now().Add(-w.maxAge)
+	kept := 0
+	for _, b := range backups {
+		if (w.maxBackups > 0 && kept >= w.maxBackups) || (w.maxAge > 0 && b.time.Before(cutoff)) {
+			if err := os.Remove(b.name); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, err.Error())
+			}
+			continue
+		}
+		kept++
+
+		if w.compress && !strings.HasSuffix(b.name, compressSuffix) {
+			if err := compressFile(b.name); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("rotatefile: %s", strings.Join(errs, "; ")) //nolint:goerr113
+	}
+
+	return nil
+}
+
+// compressFile compresses name to name+".gz", and removes name.
+func compressFile(name string) (err error) {
+	src, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("os.Open: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(name+compressSuffix, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fileMode)
+	if err != nil {
+		return fmt.Errorf("os.OpenFile: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = dst.Close()
+			_ = os.Remove(name + compressSuffix)
+		}
+	}()
+
+	gz := gzip.NewWriter(dst)
+	if _, err := io.Copy(gz, src); err != nil {
+		return fmt.Errorf("io.Copy: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("gz.Close: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("dst.Close: %w", err)
+	}
+	_ = src.Close()
+
+	if err := os.Remove(name); err != nil {
+		return fmt.Errorf("os.Remove: %w", err)
+	}
+
+	return nil
+}
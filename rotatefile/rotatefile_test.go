@@ -0,0 +1,372 @@
+package rotatefile //nolint:testpackage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kunitsucom/ilog.go"
+)
+
+type testClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *testClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *testClock) add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func withNow(now func() time.Time) Option {
+	return func(w *Writer) {
+		w.now = now
+	}
+}
+
+func newTestClock() *testClock {
+	return &testClock{t: time.Date(2023, 8, 13, 4, 38, 39, 0, time.UTC)}
+}
+
+func tempDir(t *testing.T) (dir string, cleanup func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "rotatefile")
+	if err != nil {
+		t.Fatalf("❌: ioutil.TempDir: %v", err)
+	}
+	return dir, func() { _ = os.RemoveAll(dir) }
+}
+
+// readDir returns the contents of the files in dir by name. The contents of gzip files are decompressed.
+func readDir(t *testing.T, dir string) map[string]string {
+	t.Helper()
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("❌: ioutil.ReadDir: %v", err)
+	}
+	files := make(map[string]string, len(infos))
+	for _, info := range infos {
+		b, err := ioutil.ReadFile(filepath.Join(dir, info.Name()))
+		if err != nil {
+			t.Fatalf("❌: ioutil.ReadFile: %v", err)
+		}
+		if strings.HasSuffix(info.Name(), compressSuffix) {
+			r, err := gzip.NewReader(bytes.NewReader(b))
+			if err != nil {
+				t.Fatalf("❌: gzip.NewReader: %v", err)
+			}
+			if b, err = ioutil.ReadAll(r); err != nil {
+				t.Fatalf("❌: ioutil.ReadAll: %v", err)
+			}
+		}
+		files[info.Name()] = string(b)
+	}
+	return files
+}
+
+func write(t *testing.T, w *Writer, s string) {
+	t.Helper()
+	if _, err := w.Write([]byte(s)); err != nil {
+		t.Fatalf("❌: w.Write: %v", err)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+	t.Run("success,WithMaxSize", func(t *testing.T) {
+		t.Parallel()
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		clock := newTestClock()
+		w, err := New(filepath.Join(dir, "app.log"), WithMaxSize(10), withNow(clock.now))
+		if err != nil {
+			t.Fatalf("❌: New: %v", err)
+		}
+		write(t, w, "12345\n")
+		write(t, w, "1234\n")
+		clock.add(time.Millisecond)
+		write(t, w, "123\n")
+		write(t, w, "1234567890123\n") // NOTE: larger than the max size
+		if err := w.Close(); err != nil {
+			t.Fatalf("❌: w.Close: %v", err)
+		}
+
+		expected := map[string]string{
+			"app-2023-08-13T04-38-39.000.log": "12345\n",
+			"app-2023-08-13T04-38-39.001.log": "1234\n123\n",
+			"app.log":                         "1234567890123\n",
+		}
+		if actual := readDir(t, dir); fmt.Sprint(expected) != fmt.Sprint(actual) {
+			t.Errorf("❌: expected(%v) != actual(%v)", expected, actual)
+		}
+	})
+
+	t.Run("success,WithRotationInterval", func(t *testing.T) {
+		t.Parallel()
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		clock := newTestClock()
+		w, err := New(filepath.Join(dir, "app.log"), WithRotationInterval(time.Hour), withNow(clock.now))
+		if err != nil {
+			t.Fatalf("❌: New: %v", err)
+		}
+		write(t, w, "04:38\n")
+		clock.add(21 * time.Minute)
+		write(t, w, "04:59\n")
+		clock.add(time.Minute)
+		write(t, w, "05:00\n")
+		if err := w.Rotate(); err != nil {
+			t.Fatalf("❌: w.Rotate: %v", err)
+		}
+		write(t, w, "05:00\n")
+		if err := w.Close(); err != nil {
+			t.Fatalf("❌: w.Close: %v", err)
+		}
+
+		expected := map[string]string{
+			"app-2023-08-13T05-00-39.000.log":   "04:38\n04:59\n",
+			"app-2023-08-13T05-00-39.000-1.log": "05:00\n",
+			"app.log":                           "05:00\n",
+		}
+		if actual := readDir(t, dir); fmt.Sprint(expected) != fmt.Sprint(actual) {
+			t.Errorf("❌: expected(%v) != actual(%v)", expected, actual)
+		}
+	})
+
+	t.Run("success,WithMaxBackups,WithCompress", func(t *testing.T) {
+		t.Parallel()
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		clock := newTestClock()
+		w, err := New(filepath.Join(dir, "app.log"), WithMaxSize(1), WithMaxBackups(2), WithCompress(true), withNow(clock.now))
+		if err != nil {
+			t.Fatalf("❌: New: %v", err)
+		}
+		for i := 0; i < 4; i++ {
+			write(t, w, fmt.Sprintf("%d\n", i))
+			clock.add(time.Second)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("❌: w.Close: %v", err)
+		}
+
+		expected := map[string]string{
+			"app-2023-08-13T04-38-41.000.log.gz": "1\n",
+			"app-2023-08-13T04-38-42.000.log.gz": "2\n",
+			"app.log":                            "3\n",
+		}
+		if actual := readDir(t, dir); fmt.Sprint(expected) != fmt.Sprint(actual) {
+			t.Errorf("❌: expected(%v) != actual(%v)", expected, actual)
+		}
+	})
+
+	t.Run("success,WithMaxAge", func(t *testing.T) {
+		t.Parallel()
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		for _, name := range []string{"app-2023-08-12T04-38-38.000.log.gz", "app-2023-08-12T04-38-40.000.log", "app-other.log", "other-2023-08-12T04-38-38.000.log"} {
+			if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(name), fileMode); err != nil {
+				t.Fatalf("❌: ioutil.WriteFile: %v", err)
+			}
+		}
+		clock := newTestClock()
+		w, err := New(filepath.Join(dir, "app.log"), WithMaxAge(24*time.Hour), withNow(clock.now))
+		if err != nil {
+			t.Fatalf("❌: New: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("❌: w.Close: %v", err)
+		}
+
+		expected := []string{"app-2023-08-12T04-38-40.000.log", "app-other.log", "app.log", "other-2023-08-12T04-38-38.000.log"}
+		actual := make([]string, 0, len(expected))
+		for name := range readDir(t, dir) {
+			actual = append(actual, name)
+		}
+		sort.Strings(actual)
+		if fmt.Sprint(expected) != fmt.Sprint(actual) {
+			t.Errorf("❌: expected(%v) != actual(%v)", expected, actual)
+		}
+	})
+
+	t.Run("success,Reopen", func(t *testing.T) {
+		t.Parallel()
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		w, err := New(filepath.Join(dir, "app.log"))
+		if err != nil {
+			t.Fatalf("❌: New: %v", err)
+		}
+		write(t, w, "before\n")
+		if err := os.Rename(filepath.Join(dir, "app.log"), filepath.Join(dir, "app.log.1")); err != nil {
+			t.Fatalf("❌: os.Rename: %v", err)
+		}
+		if err := w.Reopen(); err != nil {
+			t.Fatalf("❌: w.Reopen: %v", err)
+		}
+		write(t, w, "after\n")
+		if err := w.Sync(); err != nil {
+			t.Fatalf("❌: w.Sync: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("❌: w.Close: %v", err)
+		}
+
+		expected := map[string]string{
+			"app.log":   "after\n",
+			"app.log.1": "before\n",
+		}
+		if actual := readDir(t, dir); fmt.Sprint(expected) != fmt.Sprint(actual) {
+			t.Errorf("❌: expected(%v) != actual(%v)", expected, actual)
+		}
+	})
+
+	t.Run("failure,closed", func(t *testing.T) {
+		t.Parallel()
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		w, err := New(filepath.Join(dir, "app.log"))
+		if err != nil {
+			t.Fatalf("❌: New: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("❌: w.Close: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Errorf("❌: w.Close: %v", err)
+		}
+		if _, err := w.Write([]byte("closed")); !errors.Is(err, ErrClosed) {
+			t.Errorf("❌: expected(%v) != actual(%v)", ErrClosed, err)
+		}
+		if err := w.Rotate(); !errors.Is(err, ErrClosed) {
+			t.Errorf("❌: expected(%v) != actual(%v)", ErrClosed, err)
+		}
+		if err := w.Reopen(); !errors.Is(err, ErrClosed) {
+			t.Errorf("❌: expected(%v) != actual(%v)", ErrClosed, err)
+		}
+		if err := w.Sync(); !errors.Is(err, ErrClosed) {
+			t.Errorf("❌: expected(%v) != actual(%v)", ErrClosed, err)
+		}
+	})
+
+	t.Run("failure,rotate", func(t *testing.T) {
+		t.Parallel()
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		logDir := filepath.Join(dir, "log")
+		w, err := New(filepath.Join(logDir, "app.log"), WithMaxSize(10))
+		if err != nil {
+			t.Fatalf("❌: New: %v", err)
+		}
+		write(t, w, "12345\n")
+
+		// NOTE: removing the directory makes both the rotation and the reopening of the current file fail.
+		if err := os.RemoveAll(logDir); err != nil {
+			t.Fatalf("❌: os.RemoveAll: %v", err)
+		}
+		if _, err := w.Write([]byte("1234567\n")); err == nil || errors.Is(err, ErrClosed) {
+			t.Errorf("❌: expected an error other than ErrClosed: %v", err)
+		}
+		if _, err := w.Write([]byte("1234567\n")); err == nil || errors.Is(err, ErrClosed) {
+			t.Errorf("❌: expected an error other than ErrClosed: %v", err)
+		}
+
+		// NOTE: the next write opens the file again.
+		if err := os.MkdirAll(logDir, dirMode); err != nil {
+			t.Fatalf("❌: os.MkdirAll: %v", err)
+		}
+		write(t, w, "after\n")
+		if err := w.Close(); err != nil {
+			t.Fatalf("❌: w.Close: %v", err)
+		}
+		select {
+		case <-w.millDone:
+		default:
+			t.Errorf("❌: the background goroutine is not finished")
+		}
+
+		expected := map[string]string{
+			"app.log": "after\n",
+		}
+		if actual := readDir(t, logDir); fmt.Sprint(expected) != fmt.Sprint(actual) {
+			t.Errorf("❌: expected(%v) != actual(%v)", expected, actual)
+		}
+	})
+
+	t.Run("failure,Close", func(t *testing.T) {
+		t.Parallel()
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		w, err := New(filepath.Join(dir, "app.log"))
+		if err != nil {
+			t.Fatalf("❌: New: %v", err)
+		}
+		// NOTE: the file closed behind the Writer makes the rotation fail to close it.
+		_ = w.file.Close()
+		if err := w.Rotate(); err == nil {
+			t.Errorf("❌: err == nil")
+		}
+		write(t, w, "after\n")
+		if err := w.Close(); err != nil {
+			t.Fatalf("❌: w.Close: %v", err)
+		}
+
+		expected := map[string]string{
+			"app.log": "after\n",
+		}
+		if actual := readDir(t, dir); fmt.Sprint(expected) != fmt.Sprint(actual) {
+			t.Errorf("❌: expected(%v) != actual(%v)", expected, actual)
+		}
+	})
+
+	t.Run("success,ilog", func(t *testing.T) {
+		t.Parallel()
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		w, err := New(filepath.Join(dir, "app.log"), WithMaxSize(1024))
+		if err != nil {
+			t.Fatalf("❌: New: %v", err)
+		}
+		l := ilog.NewBuilder(ilog.DebugLevel, w).UseSyncWriter().Build()
+
+		const goroutines, entries = 8, 100
+		var wg sync.WaitGroup
+		for i := 0; i < goroutines; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < entries; j++ {
+					l.Int("goroutine", i).Int("entry", j).Infof("Infof")
+				}
+			}(i)
+		}
+		wg.Wait()
+		if err := w.Close(); err != nil {
+			t.Fatalf("❌: w.Close: %v", err)
+		}
+
+		lines := 0
+		for _, content := range readDir(t, dir) {
+			lines += strings.Count(content, "\n")
+		}
+		if expected, actual := goroutines*entries, lines; expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+	})
+}
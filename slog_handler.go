@@ -0,0 +1,339 @@
+//go:build go1.21
+// +build go1.21
+
+package ilog
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"runtime"
+	"time"
+)
+
+type slogHandler struct {
+	logger Logger
+	// groups is the names of the groups opened by WithGroup.
+	groups []string
+	// groupAttrs is the attributes added by WithAttrs after the first group was opened.
+	// groupAttrs[i] belongs to the group groups[:i+1].
+	groupAttrs [][]slog.Attr
+}
+
+// NewSlogHandler returns a new slog.Handler that writes log records through the specified ilog.Logger.
+// Groups are written as nested objects, and the caller of the record is preserved.
+// With the default implementation, the caller is resolved from the program counter of the record, so it is preserved even if a wrapping handler calls Handle asynchronously.
+// With the other implementations, the caller is preserved only if Handle is called synchronously by the slog.Logger.
+func NewSlogHandler(l Logger) slog.Handler { //nolint:ireturn
+	return &slogHandler{
+		logger: l,
+	}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return fromSlogLevel(level) >= h.logger.Level()
+}
+
+// callerPCLogger is implemented by the loggers that can report the caller from a program counter, such as the default implementation.
+type callerPCLogger interface {
+	withCallerPC(pc uintptr) Logger
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	var l Logger
+	if cl, ok := h.logger.(callerPCLogger); ok && r.PC != 0 {
+		// NOTE: the caller is resolved from r.PC, so it is correct even if a wrapping handler calls Handle from another goroutine.
+		l = cl.withCallerPC(r.PC)
+	} else {
+		l = h.logger.AddCallerSkip(callerSkipForSlogRecord(r.PC))
+	}
+	enc := &commonObjectEncoder{le: l.Ctx(ctx)}
+
+	if len(h.groups) == 0 {
+		r.Attrs(func(a slog.Attr) bool {
+			addSlogAttr(enc, a)
+			return true
+		})
+	} else {
+		attrs := make([]slog.Attr, 0, r.NumAttrs())
+		r.Attrs(func(a slog.Attr) bool {
+			attrs = append(attrs, a)
+			return true
+		})
+		addSlogAttr(enc, h.group(0, attrs))
+	}
+
+	enc.le.Logf(fromSlogLevel(r.Level), r.Message)
+
+	return nil
+}
+
+// group returns the group attribute of groups[i] which contains the attributes of the deeper groups and the record.
+func (h *slogHandler) group(i int, recordAttrs []slog.Attr) slog.Attr {
+	attrs := make([]interface{}, 0, len(h.groupAttrs[i])+len(recordAttrs)+1)
+	for _, a := range h.groupAttrs[i] {
+		attrs = append(attrs, a)
+	}
+
+	if i+1 < len(h.groups) {
+		attrs = append(attrs, h.group(i+1, recordAttrs))
+	} else {
+		for _, a := range recordAttrs {
+			attrs = append(attrs, a)
+		}
+	}
+
+	return slog.Group(h.groups[i], attrs...)
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler { //nolint:ireturn
+	if len(attrs) == 0 {
+		return h
+	}
+
+	copied := h.copy()
+
+	if len(copied.groups) == 0 {
+		enc := &commonObjectEncoder{le: copied.logger}
+		for _, a := range attrs {
+			addSlogAttr(enc, a)
+		}
+		if e, ok := enc.le.(LogEntry); ok {
+			copied.logger = e.Logger()
+		}
+		return copied
+	}
+
+	last := len(copied.groupAttrs) - 1
+	copied.groupAttrs[last] = append(append(make([]slog.Attr, 0, len(copied.groupAttrs[last])+len(attrs)), copied.groupAttrs[last]...), attrs...)
+	return copied
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler { //nolint:ireturn
+	if name == "" {
+		return h
+	}
+
+	copied := h.copy()
+	copied.groups = append(copied.groups, name)
+	copied.groupAttrs = append(copied.groupAttrs, nil)
+	return copied
+}
+
+func (h *slogHandler) copy() *slogHandler {
+	copied := *h
+	copied.groups = append(make([]string, 0, len(h.groups)+1), h.groups...)
+	copied.groupAttrs = append(make([][]slog.Attr, 0, len(h.groupAttrs)+1), h.groupAttrs...)
+	return &copied
+}
+
+// callerSkipForSlogRecord returns the number of stack frames between slogHandler.Handle and the caller recorded in slog.Record.
+// It is used for the loggers that do not implement callerPCLogger, and finds the caller only if Handle is called synchronously by the slog.Logger.
+func callerSkipForSlogRecord(recordPC uintptr) int {
+	if recordPC == 0 {
+		return 0
+	}
+
+	pc, put := getPCBuffer()
+	defer put()
+
+	// NOTE: skip runtime.Callers, callerSkipForSlogRecord and slogHandler.Handle
+	const skip = 3
+	n := runtime.Callers(skip, pc.pc)
+	for i := 0; i < n; i++ {
+		if pc.pc[i] == recordPC {
+			// NOTE: pc.pc[0] is the caller of slogHandler.Handle, and ilog.Logger reports the caller of slogHandler.Handle by default.
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+//nolint:cyclop
+func addSlogAttr(enc ObjectEncoder, a slog.Attr) {
+	v := a.Value.Resolve()
+
+	// NOTE: cf. https://pkg.go.dev/log/slog#Handler
+	// > If an Attr's key and value are both the zero value, ignore the Attr.
+	if a.Key == "" && v.Kind() != slog.KindGroup && v.Any() == nil {
+		return
+	}
+
+	switch v.Kind() {
+	case slog.KindBool:
+		enc.AddBool(a.Key, v.Bool())
+	case slog.KindDuration:
+		enc.AddDuration(a.Key, v.Duration())
+	case slog.KindFloat64:
+		enc.AddFloat64(a.Key, v.Float64())
+	case slog.KindInt64:
+		enc.AddInt64(a.Key, v.Int64())
+	case slog.KindString:
+		enc.AddString(a.Key, v.String())
+	case slog.KindTime:
+		enc.AddTime(a.Key, v.Time())
+	case slog.KindUint64:
+		enc.AddUint64(a.Key, v.Uint64())
+	case slog.KindGroup:
+		attrs := v.Group()
+		// NOTE: > If a group has no Attrs (even if it has a non-empty key), ignore it.
+		if len(attrs) == 0 {
+			return
+		}
+		// NOTE: > If a group's key is empty, inline the group's Attrs.
+		if a.Key == "" {
+			for _, ga := range attrs {
+				addSlogAttr(enc, ga)
+			}
+			return
+		}
+		enc.AddObject(a.Key, slogAttrs(attrs))
+	default:
+		if err, ok := v.Any().(error); ok {
+			enc.AddErr(a.Key, err)
+			return
+		}
+		enc.AddAny(a.Key, v.Any())
+	}
+}
+
+// slogAttrs is the ObjectMarshaler that adds the attributes of a slog group as the fields of a nested object.
+type slogAttrs []slog.Attr
+
+func (attrs slogAttrs) MarshalLogObject(enc ObjectEncoder) error {
+	for _, a := range attrs {
+		addSlogAttr(enc, a)
+	}
+	return nil
+}
+
+// commonObjectEncoder is the ObjectEncoder that adds fields to ilog.Logger or ilog.LogEntry.
+type commonObjectEncoder struct {
+	le common
+}
+
+func (enc *commonObjectEncoder) AddAny(key string, value interface{}) {
+	enc.le = enc.le.Any(key, value)
+}
+
+func (enc *commonObjectEncoder) AddBool(key string, value bool) {
+	enc.le = enc.le.Bool(key, value)
+}
+
+func (enc *commonObjectEncoder) AddBytes(key string, value []byte) {
+	enc.le = enc.le.Bytes(key, value)
+}
+
+func (enc *commonObjectEncoder) AddDuration(key string, value time.Duration) {
+	enc.le = enc.le.Duration(key, value)
+}
+
+func (enc *commonObjectEncoder) AddErr(key string, err error) {
+	enc.le = enc.le.ErrWithKey(key, err)
+}
+
+func (enc *commonObjectEncoder) AddFloat32(key string, value float32) {
+	enc.le = enc.le.Float32(key, value)
+}
+
+func (enc *commonObjectEncoder) AddFloat64(key string, value float64) {
+	enc.le = enc.le.Float64(key, value)
+}
+
+func (enc *commonObjectEncoder) AddInt(key string, value int) {
+	enc.le = enc.le.Int(key, value)
+}
+
+func (enc *commonObjectEncoder) AddInt32(key string, value int32) {
+	enc.le = enc.le.Int32(key, value)
+}
+
+func (enc *commonObjectEncoder) AddInt64(key string, value int64) {
+	enc.le = enc.le.Int64(key, value)
+}
+
+func (enc *commonObjectEncoder) AddObject(key string, marshaler ObjectMarshaler) {
+	enc.le = enc.le.Object(key, marshaler)
+}
+
+func (enc *commonObjectEncoder) AddString(key, value string) {
+	enc.le = enc.le.String(key, value)
+}
+
+func (enc *commonObjectEncoder) AddTime(key string, value time.Time) {
+	enc.le = enc.le.Time(key, value)
+}
+
+func (enc *commonObjectEncoder) AddUint(key string, value uint) {
+	enc.le = enc.le.Uint(key, value)
+}
+
+func (enc *commonObjectEncoder) AddUint32(key string, value uint32) {
+	enc.le = enc.le.Uint32(key, value)
+}
+
+func (enc *commonObjectEncoder) AddUint64(key string, value uint64) {
+	enc.le = enc.le.Uint64(key, value)
+}
+
+func (enc *commonObjectEncoder) AddArray(key string, marshaler ArrayMarshaler) {
+	enc.le = enc.le.Array(key, marshaler)
+}
+
+func (enc *commonObjectEncoder) AddBools(key string, values []bool) {
+	enc.le = enc.le.Bools(key, values)
+}
+
+func (enc *commonObjectEncoder) AddDurations(key string, values []time.Duration) {
+	enc.le = enc.le.Durations(key, values)
+}
+
+func (enc *commonObjectEncoder) AddErrs(key string, errs []error) {
+	enc.le = enc.le.Errs(key, errs)
+}
+
+func (enc *commonObjectEncoder) AddFloat64s(key string, values []float64) {
+	enc.le = enc.le.Float64s(key, values)
+}
+
+func (enc *commonObjectEncoder) AddInts(key string, values []int) {
+	enc.le = enc.le.Ints(key, values)
+}
+
+func (enc *commonObjectEncoder) AddInt64s(key string, values []int64) {
+	enc.le = enc.le.Int64s(key, values)
+}
+
+func (enc *commonObjectEncoder) AddStrings(key string, values []string) {
+	enc.le = enc.le.Strings(key, values)
+}
+
+func (enc *commonObjectEncoder) AddTimes(key string, values []time.Time) {
+	enc.le = enc.le.Times(key, values)
+}
+
+// fromSlogLevel converts slog.Level to ilog.Level.
+// The standard levels of ilog are twice as large as those of slog, so the other levels are scaled in the same way.
+func fromSlogLevel(level slog.Level) Level {
+	switch level {
+	case slog.LevelDebug:
+		return DebugLevel
+	case slog.LevelInfo:
+		return InfoLevel
+	case slog.LevelWarn:
+		return WarnLevel
+	case slog.LevelError:
+		return ErrorLevel
+	default:
+		const scale = 2
+		scaled := int(level) * scale
+		switch {
+		case scaled < math.MinInt8:
+			return math.MinInt8
+		case scaled > math.MaxInt8:
+			return math.MaxInt8
+		}
+		return Level(scaled)
+	}
+}
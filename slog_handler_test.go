@@ -0,0 +1,153 @@
+//go:build go1.21
+// +build go1.21
+
+package ilog //nolint:testpackage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestNewSlogHandler(t *testing.T) {
+	t.Parallel()
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		expected := regexp.MustCompilePOSIX(`{"severity":"INFO","timestamp":"[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}\.?[0-9]*Z","caller":"ilog\.go/slog_handler_test\.go:[0-9]+","message":"Info","with":"with","bool":true,"duration":"1s","float64":1\.5,"int64":-1,"string":"string","time":"2023-08-13T04:38:39\.123456789\+09:00","uint64":1,"error":"unexpected EOF","any":\["a","b"\],"inline":"inline","group":{"key":"value"}}` + "\n")
+
+		l := slog.New(NewSlogHandler(NewBuilder(DebugLevel, NewSyncWriter(buf)).SetTimestampZone(time.UTC).Build())).With("with", "with")
+		l.Info("Info",
+			slog.Bool("bool", true),
+			slog.Duration("duration", time.Second),
+			slog.Float64("float64", 1.5),
+			slog.Int64("int64", -1),
+			slog.String("string", "string"),
+			slog.Time("time", time.Date(2023, 8, 13, 4, 38, 39, 123456789, time.FixedZone("Asia/Tokyo", int(9*time.Hour/time.Second)))),
+			slog.Uint64("uint64", 1),
+			slog.Any("error", errors.New("unexpected EOF")),
+			slog.Any("any", []string{"a", "b"}),
+			slog.Attr{},
+			slog.Group("", slog.String("inline", "inline")),
+			slog.Group("empty"),
+			slog.Group("group", slog.String("key", "value")),
+		)
+
+		if !expected.Match(buf.Bytes()) {
+			t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+		}
+	})
+
+	t.Run("success,WithGroup", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		expected := regexp.MustCompilePOSIX(`{"severity":"WARN","caller":"ilog\.go/slog_handler_test\.go:[0-9]+","message":"Warn","top":"top","g1":{"a":"a","g2":{"b":"b","c":"c","d":{"e":"e"}}}}` + "\n")
+
+		l := slog.New(NewSlogHandler(NewBuilder(DebugLevel, NewSyncWriter(buf)).SetTimestampKey("").Build())).
+			With("top", "top").
+			WithGroup("g1").
+			With("a", "a").
+			WithGroup("").
+			WithGroup("g2").
+			With("b", "b")
+		l.Warn("Warn", "c", "c", slog.Group("d", "e", "e"))
+
+		if !expected.Match(buf.Bytes()) {
+			t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+		}
+	})
+
+	t.Run("success,Enabled", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		const expected = `{"severity":"WARN","message":"Warn"}
+{"severity":"ERROR","message":"Error"}
+`
+
+		h := NewSlogHandler(NewBuilder(WarnLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").Build())
+		if h.Enabled(context.Background(), slog.LevelInfo) {
+			t.Errorf("❌: h.Enabled: expected(false) != actual(true)")
+		}
+
+		l := slog.New(h)
+		l.Debug("Debug")
+		l.Info("Info")
+		l.Warn("Warn")
+		l.Error("Error")
+
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%s) != actual(%s)", expected, actual)
+		}
+	})
+}
+
+func Test_fromSlogLevel(t *testing.T) {
+	t.Parallel()
+	for slogLevel, expected := range map[slog.Level]Level{
+		slog.LevelDebug:     DebugLevel,
+		slog.LevelInfo:      InfoLevel,
+		slog.LevelWarn:      WarnLevel,
+		slog.LevelError:     ErrorLevel,
+		slog.LevelError + 1: ErrorLevel + 2,
+		-1000:               -128,
+		1000:                127,
+	} {
+		if actual := fromSlogLevel(slogLevel); expected != actual {
+			t.Errorf("❌: fromSlogLevel(%d): expected(%d) != actual(%d)", slogLevel, expected, actual)
+		}
+	}
+}
+
+//nolint:paralleltest
+func TestSlogHandler_Handle_context(t *testing.T) {
+	defer RegisterContextExtractor(ContextValue("request_id", testContextKey{}))()
+
+	buf := bytes.NewBuffer(nil)
+	defer t.Logf("ℹ️: buf:\n%s", buf)
+
+	l := slog.New(NewSlogHandler(NewBuilder(DebugLevel, NewSyncWriter(buf)).SetTimestampKey("").Build()))
+	l.InfoContext(context.WithValue(context.Background(), testContextKey{}, "req-1"), "InfoContext", slog.String("key", "value"))
+
+	expected := regexp.MustCompilePOSIX(`^{"severity":"INFO","caller":"ilog\.go/slog_handler_test\.go:[0-9]+","message":"InfoContext","request_id":"req-1","key":"value"}` + "\n$")
+	if !expected.Match(buf.Bytes()) {
+		t.Errorf("❌: !expected.Match(buf.Bytes()):\n%s", buf)
+	}
+}
+
+// testAsyncHandler is the slog.Handler that calls Handle of the wrapped handler in another goroutine.
+type testAsyncHandler struct {
+	slog.Handler
+}
+
+func (h testAsyncHandler) Handle(ctx context.Context, r slog.Record) error {
+	errc := make(chan error)
+	go func() { errc <- h.Handler.Handle(ctx, r) }()
+	return <-errc
+}
+
+func TestSlogHandler_Handle_async(t *testing.T) {
+	t.Parallel()
+	buf := bytes.NewBuffer(nil)
+	defer t.Logf("ℹ️: buf:\n%s", buf)
+
+	l := slog.New(testAsyncHandler{NewSlogHandler(NewBuilder(DebugLevel, NewSyncWriter(buf)).SetTimestampKey("").Build())})
+	_, _, line, _ := runtime.Caller(0)
+	l.Info("Info")
+
+	expected := `{"severity":"INFO","caller":"ilog.go/slog_handler_test.go:` + strconv.Itoa(line+1) + `","message":"Info"}` + "\n"
+	if actual := buf.String(); expected != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+	}
+}
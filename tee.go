@@ -0,0 +1,746 @@
+package ilog
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+)
+
+type teeLogger struct {
+	// level is the level set by SetLevel. If levelSet is false, the level is the lowest level of the loggers at the time of logging.
+	level    Level
+	levelSet bool
+	loggers  []Logger
+}
+
+// Tee returns a new Logger that writes every log entry to all the specified loggers, which may be any ilog.Logger implementations.
+// Each logger writes only the log entries at or above its own level, so each destination can have its own minimum level.
+// The level of the returned logger is the lowest level of the loggers, which is evaluated every time it logs so that the changes of LevelVar are reflected,
+// and SetLevel of the returned logger fixes the level that filters the log entries before they reach the loggers.
+//
+// A write error of a logger does not prevent the others from writing. To keep a slow destination from blocking the others, wrap its writer with NewAsyncWriter.
+func Tee(loggers ...Logger) Logger { //nolint:ireturn
+	const skip = 1 // NOTE: skip the methods of teeLogger and teeLogEntry
+	t := &teeLogger{
+		loggers: make([]Logger, len(loggers)),
+	}
+	for i, l := range loggers {
+		t.loggers[i] = l.AddCallerSkip(skip)
+	}
+
+	return t
+}
+
+func (t *teeLogger) Level() Level {
+	if t.levelSet {
+		return t.level
+	}
+	level := Level(math.MaxInt8)
+	for _, l := range t.loggers {
+		if l := l.Level(); l < level {
+			level = l
+		}
+	}
+	return level
+}
+
+func (t *teeLogger) SetLevel(level Level) Logger { //nolint:ireturn
+	copied := t.copy()
+	copied.level = level
+	copied.levelSet = true
+	return copied
+}
+
+func (t *teeLogger) AddCallerSkip(skip int) Logger { //nolint:ireturn
+	copied := t.copy()
+	for i, l := range copied.loggers {
+		copied.loggers[i] = l.AddCallerSkip(skip)
+	}
+	return copied
+}
+
+func (t *teeLogger) Copy() Logger { //nolint:ireturn
+	return t.copy()
+}
+
+func (t *teeLogger) Named(name string) Logger { //nolint:ireturn
+	copied := t.copy()
+	for i, l := range copied.loggers {
+		copied.loggers[i] = l.Named(name)
+	}
+	return copied
+}
+
+func (t *teeLogger) copy() *teeLogger {
+	copied := &teeLogger{
+		level:    t.level,
+		levelSet: t.levelSet,
+		loggers:  make([]Logger, len(t.loggers)),
+	}
+	for i, l := range t.loggers {
+		copied.loggers[i] = l.Copy()
+	}
+	return copied
+}
+
+func (t *teeLogger) Any(key string, value interface{}) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Any(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) Bool(key string, value bool) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Bool(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) Bytes(key string, value []byte) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Bytes(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) Duration(key string, value time.Duration) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Duration(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) Err(err error) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Err(err)
+	}
+	return e
+}
+
+func (t *teeLogger) ErrWithKey(key string, err error) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.ErrWithKey(key, err)
+	}
+	return e
+}
+
+func (t *teeLogger) Float32(key string, value float32) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Float32(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) Float64(key string, value float64) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Float64(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) Int(key string, value int) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Int(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) Int32(key string, value int32) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Int32(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) Int64(key string, value int64) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Int64(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) String(key, value string) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.String(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) Time(key string, value time.Time) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Time(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) Uint(key string, value uint) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Uint(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) Uint32(key string, value uint32) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Uint32(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) Uint64(key string, value uint64) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Uint64(key, value)
+	}
+	return e
+}
+
+func (t *teeLogger) Object(key string, marshaler ObjectMarshaler) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Object(key, marshaler)
+	}
+	return e
+}
+
+func (t *teeLogger) Group(name string) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Group(name)
+	}
+	return e
+}
+
+func (t *teeLogger) Array(key string, marshaler ArrayMarshaler) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Array(key, marshaler)
+	}
+	return e
+}
+
+func (t *teeLogger) Bools(key string, values []bool) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Bools(key, values)
+	}
+	return e
+}
+
+func (t *teeLogger) Durations(key string, values []time.Duration) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Durations(key, values)
+	}
+	return e
+}
+
+func (t *teeLogger) Errs(key string, errs []error) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Errs(key, errs)
+	}
+	return e
+}
+
+func (t *teeLogger) Float64s(key string, values []float64) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Float64s(key, values)
+	}
+	return e
+}
+
+func (t *teeLogger) Ints(key string, values []int) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Ints(key, values)
+	}
+	return e
+}
+
+func (t *teeLogger) Int64s(key string, values []int64) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Int64s(key, values)
+	}
+	return e
+}
+
+func (t *teeLogger) Strings(key string, values []string) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Strings(key, values)
+	}
+	return e
+}
+
+func (t *teeLogger) Times(key string, values []time.Time) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Times(key, values)
+	}
+	return e
+}
+
+func (t *teeLogger) Stack(key string) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Stack(key)
+	}
+	return e
+}
+
+func (t *teeLogger) Ctx(ctx context.Context) LogEntry { //nolint:ireturn
+	e := t.new()
+	for i, l := range t.loggers {
+		e.entries[i] = l.Ctx(ctx)
+	}
+	return e
+}
+
+func (t *teeLogger) Tracef(format string, args ...interface{}) {
+	if TraceLevel < t.Level() {
+		return
+	}
+	for _, l := range t.loggers {
+		l.Tracef(format, args...)
+	}
+}
+
+func (t *teeLogger) Debugf(format string, args ...interface{}) {
+	if DebugLevel < t.Level() {
+		return
+	}
+	for _, l := range t.loggers {
+		l.Debugf(format, args...)
+	}
+}
+
+func (t *teeLogger) Infof(format string, args ...interface{}) {
+	if InfoLevel < t.Level() {
+		return
+	}
+	for _, l := range t.loggers {
+		l.Infof(format, args...)
+	}
+}
+
+func (t *teeLogger) Warnf(format string, args ...interface{}) {
+	if WarnLevel < t.Level() {
+		return
+	}
+	for _, l := range t.loggers {
+		l.Warnf(format, args...)
+	}
+}
+
+func (t *teeLogger) Errorf(format string, args ...interface{}) {
+	if ErrorLevel < t.Level() {
+		return
+	}
+	for _, l := range t.loggers {
+		l.Errorf(format, args...)
+	}
+}
+
+// Panicf writes the log entry to all the loggers at PanicLevel without panicking in each logger, and then panics.
+func (t *teeLogger) Panicf(format string, args ...interface{}) {
+	if PanicLevel >= t.Level() {
+		for _, l := range t.loggers {
+			l.Logf(PanicLevel, format, args...)
+		}
+	}
+	_ = t.Sync()
+	panic(sprintf(format, args...))
+}
+
+// Fatalf writes the log entry to all the loggers at FatalLevel without exiting in each logger, and then exits.
+func (t *teeLogger) Fatalf(format string, args ...interface{}) {
+	if FatalLevel >= t.Level() {
+		for _, l := range t.loggers {
+			l.Logf(FatalLevel, format, args...)
+		}
+	}
+	_ = t.Sync()
+	Exit(1)
+}
+
+func (t *teeLogger) Logf(level Level, format string, args ...interface{}) {
+	if level < t.Level() {
+		return
+	}
+	for _, l := range t.loggers {
+		l.Logf(level, format, args...)
+	}
+}
+
+// Write writes p as a log entry at the level of the tee logger.
+func (t *teeLogger) Write(p []byte) (int, error) {
+	for _, l := range t.loggers {
+		l.Logf(t.Level(), string(p))
+	}
+	return len(p), nil
+}
+
+// Sync calls Sync of all the loggers that implement Syncer, even if some of them fail.
+func (t *teeLogger) Sync() error {
+	var errs teeError
+	for _, l := range t.loggers {
+		if s, ok := l.(Syncer); ok {
+			if err := s.Sync(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func (t *teeLogger) new() *teeLogEntry {
+	return &teeLogEntry{
+		logger:  t,
+		entries: make([]LogEntry, len(t.loggers)),
+	}
+}
+
+// teeError is the errors returned by the loggers of Tee.
+type teeError []error
+
+func (errs teeError) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (errs teeError) Unwrap() []error {
+	return errs
+}
+
+//nolint:errname
+type teeLogEntry struct {
+	logger  *teeLogger
+	entries []LogEntry
+}
+
+func (*teeLogEntry) Error() string {
+	return ErrLogEntryIsNotWritten.Error()
+}
+
+func (e *teeLogEntry) Any(key string, value interface{}) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Any(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Bool(key string, value bool) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Bool(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Bytes(key string, value []byte) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Bytes(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Duration(key string, value time.Duration) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Duration(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Err(err error) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Err(err)
+	}
+	return e
+}
+
+func (e *teeLogEntry) ErrWithKey(key string, err error) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.ErrWithKey(key, err)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Float32(key string, value float32) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Float32(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Float64(key string, value float64) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Float64(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Int(key string, value int) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Int(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Int32(key string, value int32) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Int32(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Int64(key string, value int64) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Int64(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) String(key, value string) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.String(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Time(key string, value time.Time) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Time(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Uint(key string, value uint) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Uint(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Uint32(key string, value uint32) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Uint32(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Uint64(key string, value uint64) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Uint64(key, value)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Object(key string, marshaler ObjectMarshaler) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Object(key, marshaler)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Group(name string) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Group(name)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Array(key string, marshaler ArrayMarshaler) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Array(key, marshaler)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Bools(key string, values []bool) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Bools(key, values)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Durations(key string, values []time.Duration) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Durations(key, values)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Errs(key string, errs []error) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Errs(key, errs)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Float64s(key string, values []float64) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Float64s(key, values)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Ints(key string, values []int) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Ints(key, values)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Int64s(key string, values []int64) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Int64s(key, values)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Strings(key string, values []string) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Strings(key, values)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Times(key string, values []time.Time) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Times(key, values)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Stack(key string) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Stack(key)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Logger() Logger { //nolint:ireturn
+	copied := &teeLogger{
+		level:    e.logger.level,
+		levelSet: e.logger.levelSet,
+		loggers:  make([]Logger, len(e.entries)),
+	}
+	for i, entry := range e.entries {
+		copied.loggers[i] = entry.Logger()
+	}
+	return copied
+}
+
+func (e *teeLogEntry) Ctx(ctx context.Context) LogEntry { //nolint:ireturn
+	for i, entry := range e.entries {
+		e.entries[i] = entry.Ctx(ctx)
+	}
+	return e
+}
+
+func (e *teeLogEntry) Tracef(format string, args ...interface{}) {
+	if TraceLevel < e.logger.Level() {
+		return
+	}
+	for _, entry := range e.entries {
+		entry.Tracef(format, args...)
+	}
+}
+
+func (e *teeLogEntry) Debugf(format string, args ...interface{}) {
+	if DebugLevel < e.logger.Level() {
+		return
+	}
+	for _, entry := range e.entries {
+		entry.Debugf(format, args...)
+	}
+}
+
+func (e *teeLogEntry) Infof(format string, args ...interface{}) {
+	if InfoLevel < e.logger.Level() {
+		return
+	}
+	for _, entry := range e.entries {
+		entry.Infof(format, args...)
+	}
+}
+
+func (e *teeLogEntry) Warnf(format string, args ...interface{}) {
+	if WarnLevel < e.logger.Level() {
+		return
+	}
+	for _, entry := range e.entries {
+		entry.Warnf(format, args...)
+	}
+}
+
+func (e *teeLogEntry) Errorf(format string, args ...interface{}) {
+	if ErrorLevel < e.logger.Level() {
+		return
+	}
+	for _, entry := range e.entries {
+		entry.Errorf(format, args...)
+	}
+}
+
+func (e *teeLogEntry) Panicf(format string, args ...interface{}) {
+	if PanicLevel >= e.logger.Level() {
+		for _, entry := range e.entries {
+			entry.Logf(PanicLevel, format, args...)
+		}
+	}
+	_ = e.logger.Sync()
+	panic(sprintf(format, args...))
+}
+
+func (e *teeLogEntry) Fatalf(format string, args ...interface{}) {
+	if FatalLevel >= e.logger.Level() {
+		for _, entry := range e.entries {
+			entry.Logf(FatalLevel, format, args...)
+		}
+	}
+	_ = e.logger.Sync()
+	Exit(1)
+}
+
+func (e *teeLogEntry) Logf(level Level, format string, args ...interface{}) {
+	if level < e.logger.Level() {
+		return
+	}
+	for _, entry := range e.entries {
+		entry.Logf(level, format, args...)
+	}
+}
+
+// Write writes p as a log entry at the level of the tee logger.
+func (e *teeLogEntry) Write(p []byte) (int, error) {
+	for _, entry := range e.entries {
+		entry.Logf(e.logger.Level(), string(p))
+	}
+	return len(p), nil
+}
@@ -0,0 +1,217 @@
+package ilog //nolint:testpackage
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type testFailingSyncer struct {
+	bytes.Buffer
+	synced int
+}
+
+func (s *testFailingSyncer) Sync() error {
+	s.synced++
+	return io.ErrClosedPipe
+}
+
+func TestTee(t *testing.T) {
+	t.Parallel()
+	t.Run("success,", func(t *testing.T) {
+		t.Parallel()
+		stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: stdout:\n%s", stdout)
+		defer t.Logf("ℹ️: stderr:\n%s", stderr)
+
+		l := Tee(
+			NewBuilder(DebugLevel, NewSyncWriter(stdout)).SetTimestampKey("").Build(),
+			NewBuilder(ErrorLevel, NewSyncWriter(stderr)).SetTimestampKey("").UseLogfmtFormat().Build(),
+		)
+		if expected, actual := DebugLevel, l.Level(); expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+
+		l.Debugf("Debugf")
+		l = l.String("logger", "logger").Logger()
+		l.Int("int", 1).Group("group").String("string", "group").Errorf("Errorf: %s", "arg")
+		l.SetLevel(InfoLevel).Infof("Infof")
+		l.SetLevel(InfoLevel).Bool("bool", true).Debugf("Debugf")
+		l.Copy().AddCallerSkip(0).Logf(WarnLevel, "Logf")
+		l.Bool("bool", true).Logf(ErrorLevel, "Logf")
+		_, _ = l.Write([]byte("Write"))
+		_, _ = l.String("entry", "entry").Write([]byte("Write"))
+
+		expectedStdout := regexp.MustCompilePOSIX(`^{"severity":"DEBUG","caller":"ilog\.go/tee_test\.go:[0-9]+","message":"Debugf"}
+{"severity":"ERROR","caller":"ilog\.go/tee_test\.go:[0-9]+","message":"Errorf: arg","logger":"logger","int":1,"group":{"string":"group"}}
+{"severity":"INFO","caller":"ilog\.go/tee_test\.go:[0-9]+","message":"Infof","logger":"logger"}
+{"severity":"WARN","caller":"ilog\.go/tee_test\.go:[0-9]+","message":"Logf","logger":"logger"}
+{"severity":"ERROR","caller":"ilog\.go/tee_test\.go:[0-9]+","message":"Logf","logger":"logger","bool":true}
+{"severity":"DEBUG","caller":"ilog\.go/tee_test\.go:[0-9]+","message":"Write","logger":"logger"}
+{"severity":"DEBUG","caller":"ilog\.go/tee_test\.go:[0-9]+","message":"Write","logger":"logger","entry":"entry"}
+$`)
+		if !expectedStdout.Match(stdout.Bytes()) {
+			t.Errorf("❌: !expected.Match(stdout.Bytes()):\n%s", stdout)
+		}
+		expectedStderr := regexp.MustCompilePOSIX(`^severity=ERROR caller=ilog\.go/tee_test\.go:[0-9]+ message="Errorf: arg" logger=logger int=1 group="{\\"string\\":\\"group\\"}"
+severity=ERROR caller=ilog\.go/tee_test\.go:[0-9]+ message=Logf logger=logger bool=true
+$`)
+		if !expectedStderr.Match(stderr.Bytes()) {
+			t.Errorf("❌: !expected.Match(stderr.Bytes()):\n%s", stderr)
+		}
+	})
+
+	t.Run("success,common", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		l := Tee(NewBuilder(DebugLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").Build())
+		for _, le := range []common{l, l.String("logger", "logger").Logger()} {
+			le.Any("any", "any").
+				Bytes("bytes", []byte("bytes")).
+				Duration("duration", 1).
+				Err(io.EOF).
+				ErrWithKey("err", io.EOF).
+				Float32("float32", 1).
+				Float64("float64", 1).
+				Int32("int32", 1).
+				Int64("int64", 1).
+				Time("time", time.Date(2023, 8, 13, 4, 38, 39, 123456789, time.FixedZone("Asia/Tokyo", int(9*time.Hour/time.Second)))).
+				Uint("uint", 1).
+				Uint32("uint32", 1).
+				Uint64("uint64", 1).
+				Object("object", testObjectMarshaler{}).
+				Array("array", ArrayMarshalerFunc(func(enc ArrayEncoder) error { enc.AppendInt(1); return nil })).
+				Bools("bools", []bool{true}).
+				Durations("durations", nil).
+				Errs("errs", nil).
+				Float64s("float64s", nil).
+				Ints("ints", nil).
+				Int64s("int64s", nil).
+				Strings("strings", nil).
+				Times("times", nil).
+				Infof("Infof")
+			le.Bool("bool", true).Debugf("Debugf")
+			le.Int("int", 1).Infof("Infof")
+			le.Stack("stack").Warnf("Warnf")
+			le.Uint("uint", 1).Errorf("Errorf")
+		}
+
+		const prefix = `{"severity":"INFO","message":"Infof",`
+		if expected, actual := prefix+`"any":"any","bytes":"bytes","duration":"1ns","error":"EOF","err":"EOF","float32":1,"float64":1,"int32":1,"int64":1,"time":"2023-08-13T04:38:39.123456789+09:00","uint":1,"uint32":1,"uint64":1,"object":{"key":"value"},"array":[1],"bools":[true],"durations":null,"errs":null,"float64s":null,"ints":null,"int64s":null,"strings":null,"times":null}`, buf.String()[:bytes.IndexByte(buf.Bytes(), '\n')]; expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+		if expected, actual := 10, bytes.Count(buf.Bytes(), []byte("\n")); expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+	})
+
+	t.Run("success,Sync", func(t *testing.T) {
+		t.Parallel()
+		ws1, ws2 := &testFailingSyncer{}, &testFailingSyncer{}
+		l := Tee(NewBuilder(DebugLevel, ws1).Build(), NewBuilder(DebugLevel, ws2).Build(), Tee())
+
+		err := l.(Syncer).Sync()
+		if err == nil {
+			t.Fatalf("❌: err == nil")
+		}
+		if expected, actual := "io: read/write on closed pipe; io: read/write on closed pipe", err.Error(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+		if expected, actual := 2, ws1.synced+ws2.synced; expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+	})
+	t.Run("success,Named", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		r := NewLevelRegistry()
+		r.Set("db", DebugLevel)
+		l := Tee(NewBuilder(InfoLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").SetLevelRegistry(r).Build())
+		db := l.Named("db")
+		if expected, actual := DebugLevel, db.Level(); expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+		db.Debugf("Debugf")
+		l.Named("http").Debugf("Debugf")
+
+		const expected = `{"severity":"DEBUG","message":"Debugf","logger":"db"}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+
+	t.Run("success,LevelVar", func(t *testing.T) {
+		t.Parallel()
+		buf := bytes.NewBuffer(nil)
+		defer t.Logf("ℹ️: buf:\n%s", buf)
+
+		v := NewLevelVar(InfoLevel)
+		l := Tee(NewBuilder(ErrorLevel, NewSyncWriter(buf)).SetTimestampKey("").SetCallerKey("").SetLevelVar(v).Build())
+		entry := l.String("key", "value")
+		l.Debugf("Debugf: 1")
+		v.Set(DebugLevel)
+		if expected, actual := DebugLevel, l.Level(); expected != actual {
+			t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+		}
+		l.Debugf("Debugf: 2")
+		entry.Debugf("Debugf: 3")
+		// NOTE: SetLevel of the tee logger fixes its level regardless of the LevelVar.
+		l.SetLevel(WarnLevel).Infof("Infof")
+
+		const expected = `{"severity":"DEBUG","message":"Debugf: 2"}` + "\n" +
+			`{"severity":"DEBUG","message":"Debugf: 3","key":"value"}` + "\n"
+		if actual := buf.String(); expected != actual {
+			t.Errorf("❌: expected(%q) != actual(%q)", expected, actual)
+		}
+	})
+}
+
+//nolint:paralleltest
+func TestTee_Fatalf(t *testing.T) {
+	stdout, stderr := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	defer t.Logf("ℹ️: stdout:\n%s", stdout)
+	defer t.Logf("ℹ️: stderr:\n%s", stderr)
+
+	var codes []int
+	defer SetExitFunc(func(code int) { codes = append(codes, code) })()
+
+	l := Tee(
+		NewBuilder(TraceLevel, NewSyncWriter(stdout)).SetTimestampKey("").SetCallerKey("").Build(),
+		NewBuilder(FatalLevel, NewSyncWriter(stderr)).SetTimestampKey("").SetCallerKey("").Build(),
+	)
+	l.Tracef("Tracef")
+	l.String("key", "value").Tracef("Tracef")
+	l.Fatalf("Fatalf")
+	l.String("key", "value").Fatalf("Fatalf")
+	func() {
+		defer func() {
+			if expected, actual := "Panicf: 1", recover(); expected != actual {
+				t.Errorf("❌: expected(%v) != actual(%v)", expected, actual)
+			}
+		}()
+		l.String("key", "value").Panicf("Panicf: %d", 1)
+	}()
+
+	const expectedStdout = `{"severity":"TRACE","message":"Tracef"}` + "\n" +
+		`{"severity":"TRACE","message":"Tracef","key":"value"}` + "\n" +
+		`{"severity":"FATAL","message":"Fatalf"}` + "\n" +
+		`{"severity":"FATAL","message":"Fatalf","key":"value"}` + "\n" +
+		`{"severity":"PANIC","message":"Panicf: 1","key":"value"}` + "\n"
+	if actual := stdout.String(); expectedStdout != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expectedStdout, actual)
+	}
+	const expectedStderr = `{"severity":"FATAL","message":"Fatalf"}` + "\n" +
+		`{"severity":"FATAL","message":"Fatalf","key":"value"}` + "\n"
+	if actual := stderr.String(); expectedStderr != actual {
+		t.Errorf("❌: expected(%q) != actual(%q)", expectedStderr, actual)
+	}
+	if expected, actual := 2, len(codes); expected != actual {
+		t.Errorf("❌: expected(%d) != actual(%d)", expected, actual)
+	}
+}